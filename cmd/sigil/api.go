@@ -0,0 +1,80 @@
+package main
+
+// Request and response bodies of the HTTP API. The OpenAPI document is
+// generated from these types: fields without omitempty are required, and
+// the desc tag becomes the field description.
+
+type markRequest struct {
+	Resource      string            `json:"resource" desc:"Resource key, such as s3 or azurerm_storage_account."`
+	Qualifier     string            `json:"qualifier,omitempty" desc:"Free-form qualifier appended by the recipe."`
+	Zone          string            `json:"zone,omitempty" desc:"Zone for zonal resources."`
+	Regions       []string          `json:"regions,omitempty" desc:"Regions spanned by the name; their codes are joined."`
+	Overrides     map[string]string `json:"overrides,omitempty" desc:"Component values that replace the policy values."`
+	Recipe        []string          `json:"recipe,omitempty" desc:"Recipe for this name only."`
+	StylePriority []string          `json:"style_priority,omitempty" desc:"Style priority for this name only."`
+	DisplayRecipe []string          `json:"display_recipe,omitempty" desc:"Display recipe for this name only."`
+	DisplayStyle  string            `json:"display_style,omitempty" desc:"Display style for this name only: title, sentence, or lower."`
+	Parent        map[string]string `json:"parent,omitempty" desc:"Components of the parent name, usually the components of an earlier response."`
+	ParentMode    string            `json:"parent_mode,omitempty" desc:"How parent is applied: auto, drop, or reuse."`
+	ScopeKey      string            `json:"scope_key,omitempty" desc:"Explicit uniqueness scope key."`
+	Fifo          bool              `json:"fifo,omitempty" desc:"Append .fifo for resources with a FIFO variant, such as sqs and sns."`
+}
+
+type markResponse struct {
+	Name            string            `json:"name" desc:"Generated name."`
+	DisplayName     string            `json:"display_name" desc:"Human-readable name."`
+	Style           string            `json:"style" desc:"Style the name was formatted in."`
+	Components      map[string]string `json:"components" desc:"Component values used to build the name."`
+	RegionCode      string            `json:"region_code" desc:"Region code in the name, empty when omitted."`
+	ZoneCode        string            `json:"zone_code" desc:"Zone code in the name, empty when omitted."`
+	ResourceAcronym string            `json:"resource_acronym" desc:"Resource acronym in the name."`
+	Scope           string            `json:"scope" desc:"Uniqueness scope of the resource."`
+	ScopeKey        string            `json:"scope_key" desc:"Key of the uniqueness scope."`
+	Warnings        []string          `json:"warnings" desc:"Non-fatal issues, such as transliterated characters and constraint violations whose enforcement is warn."`
+}
+
+type validateRequest struct {
+	Resource string `json:"resource" desc:"Resource key whose constraint is checked."`
+	Name     string `json:"name" desc:"Name to check."`
+}
+
+type validateResponse struct {
+	Valid    bool   `json:"valid" desc:"Whether the name satisfies the resource constraint."`
+	Category string `json:"category,omitempty" desc:"Violated constraint category: length, pattern, or forbidden."`
+	Reason   string `json:"reason,omitempty" desc:"Why the name is invalid."`
+}
+
+type parseRequest struct {
+	Resource string `json:"resource" desc:"Resource key the name was built for."`
+	Name     string `json:"name" desc:"Name to split into components."`
+}
+
+type parseResponse struct {
+	Resource   string            `json:"resource" desc:"Normalized resource key."`
+	Style      string            `json:"style" desc:"Style the name is expected in."`
+	Components map[string]string `json:"components" desc:"Component values found in the name."`
+}
+
+type catalogResponse struct {
+	Cloud     string         `json:"cloud" desc:"Cloud of the policy."`
+	Resources []catalogEntry `json:"resources" desc:"Known resources, sorted by key."`
+}
+
+type catalogEntry struct {
+	Resource           string   `json:"resource" desc:"Resource key."`
+	Acronym            string   `json:"acronym" desc:"Acronym used in names."`
+	Styles             []string `json:"styles" desc:"Allowed styles."`
+	Regional           bool     `json:"regional" desc:"Whether names are unique per region."`
+	Zonal              bool     `json:"zonal" desc:"Whether the resource lives in a zone."`
+	Scope              string   `json:"scope" desc:"Uniqueness scope."`
+	MinLength          int      `json:"min_length" desc:"Minimum name length, 0 when unconstrained."`
+	MaxLength          int      `json:"max_length" desc:"Maximum name length, 0 when unconstrained."`
+	PatternDescription string   `json:"pattern_description" desc:"Description of the name pattern."`
+	Tags               []string `json:"tags" desc:"Catalog tags."`
+}
+
+type errorResponse struct {
+	Error     string `json:"error" desc:"Error message."`
+	Category  string `json:"category,omitempty" desc:"Constraint category for constraint violations."`
+	Component string `json:"component,omitempty" desc:"Offending component for component rule violations."`
+}
@@ -0,0 +1,94 @@
+// Command sigil runs the Sigil naming engine outside Terraform.
+//
+// Usage:
+//
+//	sigil serve -policy policy.json [-addr 127.0.0.1:8080] [-reload-interval 2s]
+//
+// The serve mode exposes the naming engine as a JSON HTTP API for pipelines
+// such as Backstage templates and ticketing bots. The policy file is a strict
+// subset of the provider settings, written as JSON, and is reloaded when it
+// changes. Provider layering
+// (config and overrides), resource aliases, acronym collision checks, and
+// duplicate detection are not supported.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "serve":
+		if err := serve(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "sigil: %v\n", err)
+			os.Exit(1)
+		}
+	case "-h", "-help", "--help", "help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "sigil: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: sigil serve -policy policy.json [-addr 127.0.0.1:8080] [-reload-interval 2s]")
+}
+
+func serve(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	policyPath := flags.String("policy", "", "path to the JSON policy file")
+	addr := flags.String("addr", "127.0.0.1:8080", "address to listen on")
+	reloadInterval := flags.Duration("reload-interval", 2*time.Second, "how often the policy file is checked for changes; 0 disables reloading")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *policyPath == "" {
+		return errors.New("-policy is required")
+	}
+
+	logger := log.New(os.Stderr, "sigil: ", log.LstdFlags)
+	srv, err := newServer(*policyPath, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *reloadInterval > 0 {
+		go srv.watch(ctx, *reloadInterval)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	logger.Printf("serving %s on %s", *policyPath, *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// apiVersion is the version reported in the OpenAPI document.
+const apiVersion = "1.0.0"
+
+type operation struct {
+	method   string
+	path     string
+	summary  string
+	request  any
+	response any
+	query    []any
+}
+
+// operations lists the endpoints of the API. The OpenAPI document is built
+// from it, so adding an endpoint here documents it.
+var operations = []operation{
+	{method: http.MethodPost, path: "/v1/mark", summary: "Build a name", request: markRequest{}, response: markResponse{}},
+	{method: http.MethodPost, path: "/v1/validate", summary: "Check a name against its resource constraint", request: validateRequest{}, response: validateResponse{}},
+	{method: http.MethodPost, path: "/v1/parse", summary: "Split a name into its components", request: parseRequest{}, response: parseResponse{}},
+	{method: http.MethodGet, path: "/v1/catalog", summary: "List known resources", response: catalogResponse{}, query: []any{
+		queryParameter("prefix", "Only resources whose key starts with this prefix.", map[string]any{"type": "string"}),
+		queryParameter("tag", "Only resources with every given tag. Repeatable.", map[string]any{"type": "array", "items": map[string]any{"type": "string"}}),
+	}},
+}
+
+// openAPIDocument returns an OpenAPI 3.0 document generated from the
+// request and response types of operations.
+func openAPIDocument() map[string]any {
+	schemas := map[string]any{}
+	errorRef := schemaRef(reflect.TypeOf(errorResponse{}), schemas)
+
+	paths := map[string]any{}
+	for _, op := range operations {
+		spec := map[string]any{
+			"summary":     op.summary,
+			"operationId": strings.TrimPrefix(strings.ReplaceAll(op.path, "/", "_"), "_"),
+			"responses": map[string]any{
+				"200": jsonContent("Success", schemaRef(reflect.TypeOf(op.response), schemas)),
+				"400": jsonContent("Invalid request", errorRef),
+				"422": jsonContent("Naming rule violation", errorRef),
+			},
+		}
+		if op.request != nil {
+			spec["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaRef(reflect.TypeOf(op.request), schemas)},
+				},
+			}
+		}
+		if len(op.query) > 0 {
+			spec["parameters"] = op.query
+		}
+		paths[op.path] = map[string]any{strings.ToLower(op.method): spec}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Sigil naming service",
+			"version": apiVersion,
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func jsonContent(description string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": schema},
+		},
+	}
+}
+
+func queryParameter(name, description string, schema map[string]any) map[string]any {
+	return map[string]any{"name": name, "in": "query", "description": description, "schema": schema}
+}
+
+// schemaRef registers the schema of a struct type and returns a reference
+// to it.
+func schemaRef(t reflect.Type, schemas map[string]any) map[string]any {
+	name := schemaName(t)
+	if _, ok := schemas[name]; !ok {
+		schemas[name] = objectSchema(t, schemas)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func objectSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema := typeSchema(field.Type, schemas)
+		if desc := field.Tag.Get("desc"); desc != "" {
+			schema["description"] = desc
+		}
+		properties[name] = schema
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), schemas)}
+	case reflect.Struct:
+		return schemaRef(t, schemas)
+	default:
+		panic("openapi: unsupported field type " + t.String())
+	}
+}
+
+// schemaName turns an unexported type name such as markRequest into
+// MarkRequest.
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
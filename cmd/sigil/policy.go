@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
+)
+
+// policy is the JSON form of the provider settings, as a single layer. It is
+// a strict subset of the provider schema: field names match the provider
+// attributes, and the settings in unsupportedPolicyFields have no meaning
+// outside Terraform and are rejected. TestPolicyFieldsMatchProviderSettings
+// keeps the two in sync.
+type policy struct {
+	Cloud                            string                         `json:"cloud"`
+	OrgPrefix                        string                         `json:"org_prefix"`
+	Project                          string                         `json:"project"`
+	Env                              string                         `json:"env"`
+	EnvMap                           map[string]string              `json:"env_map"`
+	AllowedEnvs                      []string                       `json:"allowed_envs"`
+	Region                           string                         `json:"region"`
+	Regions                          []string                       `json:"regions"`
+	StrictRegions                    bool                           `json:"strict_regions"`
+	RegionShortCode                  string                         `json:"region_short_code"`
+	RegionMap                        map[string]string              `json:"region_map"`
+	RegionOverrides                  map[string]string              `json:"region_overrides"`
+	Zone                             string                         `json:"zone"`
+	Recipe                           []string                       `json:"recipe"`
+	StylePriority                    []string                       `json:"style_priority"`
+	ResourceAcronyms                 map[string]string              `json:"resource_acronyms"`
+	ResourceStyleOverrides           map[string][]string            `json:"resource_style_overrides"`
+	ResourceRecipes                  map[string][]string            `json:"resource_recipes"`
+	DisplayRecipe                    []string                       `json:"display_recipe"`
+	DisplayStyle                     string                         `json:"display_style"`
+	DisplayExpansions                map[string]string              `json:"display_expansions"`
+	ComponentRules                   map[string]policyComponentRule `json:"component_rules"`
+	WordSplit                        string                         `json:"word_split"`
+	Transliteration                  string                         `json:"transliteration"`
+	IgnoreRegionForRegionalResources *bool                          `json:"ignore_region_for_regional_resources"`
+	Enforcement                      string                         `json:"enforcement"`
+	EnforcementOverrides             map[string]string              `json:"enforcement_overrides"`
+	DeniedWords                      []string                       `json:"denied_words"`
+	DeniedPatterns                   []string                       `json:"denied_patterns"`
+	DenyReservedWords                bool                           `json:"deny_reserved_words"`
+	HashInputs                       []string                       `json:"hash_inputs"`
+	HashAlgorithm                    string                         `json:"hash_algorithm"`
+	HashEncoding                     string                         `json:"hash_encoding"`
+	HashLength                       int                            `json:"hash_length"`
+	Account                          string                         `json:"account"`
+	Subscription                     string                         `json:"subscription"`
+	GCPProject                       string                         `json:"gcp_project"`
+	AccountMap                       map[string]string              `json:"account_map"`
+}
+
+// unsupportedPolicyFields lists the provider settings a policy cannot hold,
+// with the reason.
+var unsupportedPolicyFields = map[string]string{
+	"config":                   "layering is a provider feature; merge the layers into one policy file",
+	"overrides":                "layering is a provider feature; merge the layers into one policy file",
+	"resource_aliases":         "aliases only silence acronym collision checks, which run at provider configuration",
+	"acronym_collision_policy": "acronym collisions are only checked at provider configuration",
+	"duplicate_detection":      "the service does not record the names it returns",
+}
+
+type policyComponentRule struct {
+	MaxLength     int      `json:"max_length"`
+	Pattern       string   `json:"pattern"`
+	AllowedValues []string `json:"allowed_values"`
+	Required      bool     `json:"required"`
+}
+
+// loadPolicy reads the policy file at path and builds a Namer from it.
+func loadPolicy(path string) (*sigil.Namer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("decode policy %s: %w", path, err)
+	}
+	unsupported := []string{}
+	for field := range fields {
+		if _, ok := unsupportedPolicyFields[field]; ok {
+			unsupported = append(unsupported, field)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return nil, fmt.Errorf("policy %s: %s is not supported by sigil serve: %s", path, unsupported[0], unsupportedPolicyFields[unsupported[0]])
+	}
+
+	var p policy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("decode policy %s: %w", path, err)
+	}
+	if p.OrgPrefix == "" {
+		return nil, fmt.Errorf("policy %s: org_prefix is required", path)
+	}
+	if p.Env == "" {
+		return nil, fmt.Errorf("policy %s: env is required", path)
+	}
+
+	// The provider drops the region from regional resources unless told
+	// otherwise; the policy keeps that default.
+	ignoreRegion := true
+	if p.IgnoreRegionForRegionalResources != nil {
+		ignoreRegion = *p.IgnoreRegionForRegionalResources
+	}
+	opts := []sigil.Option{
+		sigil.WithCloud(p.Cloud),
+		sigil.WithOrgPrefix(p.OrgPrefix),
+		sigil.WithProject(p.Project),
+		sigil.WithEnv(p.Env),
+		sigil.WithEnvMap(p.EnvMap),
+		sigil.WithAllowedEnvs(p.AllowedEnvs...),
+		sigil.WithRegion(p.Region),
+		sigil.WithRegions(p.Regions...),
+		sigil.WithStrictRegions(p.StrictRegions),
+		sigil.WithRegionShortCode(p.RegionShortCode),
+		sigil.WithRegionMap(p.RegionMap),
+		sigil.WithRegionOverrides(p.RegionOverrides),
+		sigil.WithZone(p.Zone),
+		sigil.WithRecipe(p.Recipe...),
+		sigil.WithStylePriority(p.StylePriority...),
+		sigil.WithResourceAcronyms(p.ResourceAcronyms),
+		sigil.WithResourceStyleOverrides(p.ResourceStyleOverrides),
+		sigil.WithResourceRecipes(p.ResourceRecipes),
+		sigil.WithDisplayRecipe(p.DisplayRecipe...),
+		sigil.WithDisplayStyle(p.DisplayStyle),
+		sigil.WithDisplayExpansions(p.DisplayExpansions),
+		sigil.WithWordSplit(p.WordSplit),
+		sigil.WithTransliteration(p.Transliteration),
+		sigil.WithIgnoreRegionForRegionalResources(ignoreRegion),
+		sigil.WithEnforcement(p.Enforcement),
+		sigil.WithEnforcementOverrides(p.EnforcementOverrides),
+		sigil.WithDeniedWords(p.DeniedWords...),
+		sigil.WithDeniedPatterns(p.DeniedPatterns...),
+		sigil.WithDenyReservedWords(p.DenyReservedWords),
+		sigil.WithHashInputs(p.HashInputs...),
+		sigil.WithHashAlgorithm(p.HashAlgorithm),
+		sigil.WithHashEncoding(p.HashEncoding),
+		sigil.WithHashLength(p.HashLength),
+		sigil.WithAccount(p.Account),
+		sigil.WithSubscription(p.Subscription),
+		sigil.WithGCPProject(p.GCPProject),
+		sigil.WithAccountMap(p.AccountMap),
+	}
+	if len(p.ComponentRules) > 0 {
+		rules := make(map[string]sigil.ComponentRule, len(p.ComponentRules))
+		for key, rule := range p.ComponentRules {
+			compiled := sigil.ComponentRule{
+				MaxLen:        rule.MaxLength,
+				AllowedValues: rule.AllowedValues,
+				Required:      rule.Required,
+			}
+			if rule.Pattern != "" {
+				pattern, err := regexp.Compile(rule.Pattern)
+				if err != nil {
+					return nil, fmt.Errorf("policy %s: component_rules[%q].pattern: %w", path, key, err)
+				}
+				compiled.Pattern = pattern
+			}
+			rules[key] = compiled
+		}
+		opts = append(opts, sigil.WithComponentRules(rules))
+	}
+
+	namer, err := sigil.New(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("policy %s: %w", path, err)
+	}
+	return namer, nil
+}
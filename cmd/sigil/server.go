@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
+)
+
+// maxRequestBytes bounds request bodies; naming requests are tiny.
+const maxRequestBytes = 64 << 10
+
+type server struct {
+	policyPath string
+	logger     *log.Logger
+	namer      atomic.Pointer[sigil.Namer]
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+func newServer(policyPath string, logger *log.Logger) (*server, error) {
+	s := &server{policyPath: policyPath, logger: logger}
+	if _, err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// reload loads the policy when the file changed since the last load. A
+// policy that fails to load leaves the previous one in place.
+func (s *server) reload() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.policyPath)
+	if err != nil {
+		return false, err
+	}
+	if s.namer.Load() != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return false, nil
+	}
+
+	namer, err := loadPolicy(s.policyPath)
+	if err != nil {
+		return false, err
+	}
+	for _, unknown := range namer.UnknownRegions() {
+		s.logger.Printf("policy %s: %v", s.policyPath, &unknown)
+	}
+	s.namer.Store(namer)
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return true, nil
+}
+
+// watch polls the policy file until ctx is done.
+func (s *server) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := s.reload()
+			switch {
+			case err != nil:
+				s.logger.Printf("policy reload failed, keeping the previous policy: %v", err)
+			case reloaded:
+				s.logger.Printf("policy reloaded from %s", s.policyPath)
+			}
+		}
+	}
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/mark", s.handleMark)
+	mux.HandleFunc("POST /v1/validate", s.handleValidate)
+	mux.HandleFunc("POST /v1/parse", s.handleParse)
+	mux.HandleFunc("GET /v1/catalog", s.handleCatalog)
+	mux.HandleFunc("GET /v1/openapi.json", s.handleOpenAPI)
+	return mux
+}
+
+func (s *server) handleMark(w http.ResponseWriter, r *http.Request) {
+	var req markRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Resource) == "" {
+		writeError(w, http.StatusBadRequest, errorResponse{Error: "resource is required"})
+		return
+	}
+
+	result, err := s.namer.Load().Name(sigil.Input{
+		Resource:      req.Resource,
+		Qualifier:     req.Qualifier,
+		Zone:          req.Zone,
+		Regions:       req.Regions,
+		Overrides:     req.Overrides,
+		Recipe:        req.Recipe,
+		StylePriority: req.StylePriority,
+		DisplayRecipe: req.DisplayRecipe,
+		DisplayStyle:  req.DisplayStyle,
+		Parent:        req.Parent,
+		ParentMode:    req.ParentMode,
+		ScopeKey:      req.ScopeKey,
+		Fifo:          req.Fifo,
+	})
+	if err != nil {
+		writeNamingError(w, err)
+		return
+	}
+
+	warnings := append([]string{}, result.Warnings...)
+	for i := range result.Violations {
+		warnings = append(warnings, result.Violations[i].Error())
+	}
+	for i := range result.UnknownRegions {
+		warnings = append(warnings, result.UnknownRegions[i].Error())
+	}
+	writeJSON(w, http.StatusOK, markResponse{
+		Name:            result.Name,
+		DisplayName:     result.DisplayName,
+		Style:           result.Style,
+		Components:      result.Components,
+		RegionCode:      result.RegionCode,
+		ZoneCode:        result.ZoneCode,
+		ResourceAcronym: result.ResourceAcronym,
+		Scope:           result.Scope,
+		ScopeKey:        result.ScopeKey,
+		Warnings:        warnings,
+	})
+}
+
+func (s *server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var req validateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Resource) == "" || req.Name == "" {
+		writeError(w, http.StatusBadRequest, errorResponse{Error: "resource and name are required"})
+		return
+	}
+
+	err := s.namer.Load().Validate(req.Resource, req.Name)
+	var constraintErr *sigil.ConstraintError
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, validateResponse{Valid: true})
+	case errors.As(err, &constraintErr):
+		writeJSON(w, http.StatusOK, validateResponse{Category: constraintErr.Category, Reason: constraintErr.Error()})
+	default:
+		writeNamingError(w, err)
+	}
+}
+
+func (s *server) handleParse(w http.ResponseWriter, r *http.Request) {
+	var req parseRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Resource) == "" || req.Name == "" {
+		writeError(w, http.StatusBadRequest, errorResponse{Error: "resource and name are required"})
+		return
+	}
+
+	parsed, err := s.namer.Load().Parse(req.Resource, req.Name)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, parseResponse{
+		Resource:   parsed.Resource,
+		Style:      parsed.Style,
+		Components: parsed.Components,
+	})
+}
+
+func (s *server) handleCatalog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	namer := s.namer.Load()
+	entries := namer.Catalog(sigil.CatalogFilter{
+		Prefix: query.Get("prefix"),
+		Tags:   query["tag"],
+	})
+
+	resources := make([]catalogEntry, 0, len(entries))
+	for _, entry := range entries {
+		resources = append(resources, catalogEntry{
+			Resource:           entry.Resource,
+			Acronym:            entry.Acronym,
+			Styles:             entry.Styles,
+			Regional:           entry.Regional,
+			Zonal:              entry.Zonal,
+			Scope:              entry.Scope,
+			MinLength:          entry.MinLen,
+			MaxLength:          entry.MaxLen,
+			PatternDescription: entry.PatternDescription,
+			Tags:               entry.Tags,
+		})
+	}
+	writeJSON(w, http.StatusOK, catalogResponse{Cloud: namer.Cloud(), Resources: resources})
+}
+
+func (s *server) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, openAPIDocument())
+}
+
+// decodeRequest decodes a JSON request body into v, rejecting unknown
+// fields and trailing data. It writes the error response and reports false
+// when the body is invalid.
+func decodeRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return false
+	}
+	if decoder.More() {
+		writeError(w, http.StatusBadRequest, errorResponse{Error: "invalid request body: unexpected data after the JSON object"})
+		return false
+	}
+	return true
+}
+
+// writeNamingError maps naming errors to responses: rule violations are
+// 422 with their category or component, anything else is 400.
+func writeNamingError(w http.ResponseWriter, err error) {
+	var constraintErr *sigil.ConstraintError
+	var componentErr *sigil.ComponentError
+	switch {
+	case errors.As(err, &constraintErr):
+		writeError(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error(), Category: constraintErr.Category})
+	case errors.As(err, &componentErr):
+		writeError(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error(), Component: componentErr.Component})
+	default:
+		writeError(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, body errorResponse) {
+	writeJSON(w, status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
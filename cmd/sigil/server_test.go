@@ -0,0 +1,393 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/provider"
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
+)
+
+const testPolicy = `{
+  "cloud": "aws",
+  "org_prefix": "acme",
+  "project": "shop",
+  "env": "dev",
+  "region": "eu-west-1"
+}`
+
+func newTestServer(t *testing.T, policyJSON string) (*server, *httptest.Server, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(policyJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srv, err := newServer(path, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ts := httptest.NewServer(srv.handler())
+	t.Cleanup(ts.Close)
+	return srv, ts, path
+}
+
+func doJSON(t *testing.T, method, url, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestMark(t *testing.T) {
+	_, ts, _ := newTestServer(t, testPolicy)
+
+	// Regional resources drop the region, as in the provider.
+	var got markResponse
+	status := doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "s3", "qualifier": "assets"}`, &got)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if got.Name != "acme-shop-dev-s3b-assets" {
+		t.Fatalf("unexpected name %q", got.Name)
+	}
+
+	status = doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "route53_record", "qualifier": "api"}`, &got)
+	if status != http.StatusOK || got.Name != "acme-shop-dev-euw1-r53r-api" {
+		t.Fatalf("unexpected response %d %q", status, got.Name)
+	}
+}
+
+func TestMarkForwardsDisplayAndParentInputs(t *testing.T) {
+	_, ts, path := newTestServer(t, testPolicy)
+	namer, err := loadPolicy(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var parent markResponse
+	if status := doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "vpc", "qualifier": "core"}`, &parent); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	body, err := json.Marshal(markRequest{
+		Resource:      "subnet",
+		Qualifier:     "core",
+		DisplayRecipe: []string{"proj", "-", "qualifier"},
+		DisplayStyle:  "lower",
+		Parent:        parent.Components,
+		ParentMode:    "drop",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got markResponse
+	if status := doJSON(t, http.MethodPost, ts.URL+"/v1/mark", string(body), &got); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+
+	want, err := namer.Name(sigil.Input{
+		Resource:      "subnet",
+		Qualifier:     "core",
+		DisplayRecipe: []string{"proj", "-", "qualifier"},
+		DisplayStyle:  "lower",
+		Parent:        parent.Components,
+		ParentMode:    "drop",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != want.Name || got.DisplayName != want.DisplayName {
+		t.Fatalf("expected %q / %q as from the library, got %q / %q", want.Name, want.DisplayName, got.Name, got.DisplayName)
+	}
+	if got.DisplayName != "shop - core" {
+		t.Fatalf("expected request display settings to apply, got %q", got.DisplayName)
+	}
+	var plain markResponse
+	doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "subnet", "qualifier": "core"}`, &plain)
+	if got.Name == plain.Name {
+		t.Fatalf("expected parent_mode drop to change the name, got %q for both", got.Name)
+	}
+
+	if status := doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "subnet", "display_style": "titel"}`, nil); status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid display_style, got %d", status)
+	}
+}
+
+func TestMarkRejectsInvalidRequests(t *testing.T) {
+	_, ts, _ := newTestServer(t, testPolicy)
+
+	cases := []struct {
+		name   string
+		method string
+		body   string
+		status int
+	}{
+		{name: "missing resource", method: http.MethodPost, body: `{"qualifier": "x"}`, status: http.StatusBadRequest},
+		{name: "unknown field", method: http.MethodPost, body: `{"resource": "s3", "colour": "red"}`, status: http.StatusBadRequest},
+		{name: "malformed", method: http.MethodPost, body: `{"resource":`, status: http.StatusBadRequest},
+		{name: "trailing data", method: http.MethodPost, body: `{"resource": "s3"} {}`, status: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodGet, body: ``, status: http.StatusMethodNotAllowed},
+		{name: "constraint", method: http.MethodPost, body: `{"resource": "s3", "qualifier": "` + strings.Repeat("x", 80) + `"}`, status: http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if status := doJSON(t, tc.method, ts.URL+"/v1/mark", tc.body, nil); status != tc.status {
+				t.Fatalf("expected %d, got %d", tc.status, status)
+			}
+		})
+	}
+
+	var got errorResponse
+	doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "s3", "qualifier": "`+strings.Repeat("x", 80)+`"}`, &got)
+	if got.Category != "length" {
+		t.Fatalf("expected length category, got %+v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	_, ts, _ := newTestServer(t, testPolicy)
+
+	var got validateResponse
+	if status := doJSON(t, http.MethodPost, ts.URL+"/v1/validate", `{"resource": "s3", "name": "acme-assets"}`, &got); status != http.StatusOK || !got.Valid {
+		t.Fatalf("expected a valid name, got %d %+v", status, got)
+	}
+	got = validateResponse{}
+	if status := doJSON(t, http.MethodPost, ts.URL+"/v1/validate", `{"resource": "s3", "name": "Acme_Assets"}`, &got); status != http.StatusOK || got.Valid || got.Category != "pattern" {
+		t.Fatalf("expected a pattern violation, got %d %+v", status, got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	_, ts, _ := newTestServer(t, testPolicy)
+
+	var got parseResponse
+	status := doJSON(t, http.MethodPost, ts.URL+"/v1/parse", `{"resource": "s3", "name": "acme-shop-dev-s3b-assets"}`, &got)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if got.Components["qualifier"] != "assets" || got.Components["env"] != "dev" {
+		t.Fatalf("unexpected components %v", got.Components)
+	}
+
+	if status := doJSON(t, http.MethodPost, ts.URL+"/v1/parse", `{"resource": "s3", "name": "other-shop-dev-s3b-assets"}`, nil); status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", status)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	_, ts, _ := newTestServer(t, testPolicy)
+
+	var got catalogResponse
+	if status := doJSON(t, http.MethodGet, ts.URL+"/v1/catalog?prefix=sq&tag=regional", "", &got); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if got.Cloud != "aws" || len(got.Resources) == 0 {
+		t.Fatalf("unexpected catalog %+v", got)
+	}
+	for _, entry := range got.Resources {
+		if !strings.HasPrefix(entry.Resource, "sq") || !entry.Regional {
+			t.Fatalf("entry %+v does not match the filter", entry)
+		}
+	}
+}
+
+func TestPolicyReload(t *testing.T) {
+	srv, ts, path := newTestServer(t, testPolicy)
+
+	updated := strings.Replace(testPolicy, `"acme"`, `"globex"`, 1)
+	if err := os.WriteFile(path, []byte(updated), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, err := srv.reload(); err != nil || !reloaded {
+		t.Fatalf("expected a reload, got %v %v", reloaded, err)
+	}
+
+	var got markResponse
+	doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "s3"}`, &got)
+	if !strings.HasPrefix(got.Name, "globex-") {
+		t.Fatalf("expected the reloaded policy, got %q", got.Name)
+	}
+
+	// A broken policy keeps the previous one.
+	if err := os.WriteFile(path, []byte(`{"org_prefix": `), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := future.Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.reload(); err == nil {
+		t.Fatal("expected the broken policy to fail")
+	}
+	doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "s3"}`, &got)
+	if !strings.HasPrefix(got.Name, "globex-") {
+		t.Fatalf("expected the previous policy, got %q", got.Name)
+	}
+}
+
+func TestLoadPolicyRejectsUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"org_prefix": "acme", "env": "dev", "regoin": "eu-west-1"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadPolicy(path); err == nil || !strings.Contains(err.Error(), "regoin") {
+		t.Fatalf("expected an unknown field error, got %v", err)
+	}
+}
+
+func TestLoadPolicyRejectsProviderOnlySettings(t *testing.T) {
+	for _, field := range []string{"config", "overrides", "resource_aliases", "acronym_collision_policy", "duplicate_detection"} {
+		path := filepath.Join(t.TempDir(), "policy.json")
+		policyJSON := `{"org_prefix": "acme", "env": "dev", "` + field + `": null}`
+		if err := os.WriteFile(path, []byte(policyJSON), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadPolicy(path); err == nil || !strings.Contains(err.Error(), field+" is not supported") {
+			t.Fatalf("expected %s to be rejected as unsupported, got %v", field, err)
+		}
+	}
+}
+
+// TestPolicyFieldsMatchProviderSettings keeps the policy format a strict
+// subset of the provider schema: every policy field is a provider setting,
+// and every provider setting is either accepted or listed as unsupported.
+func TestPolicyFieldsMatchProviderSettings(t *testing.T) {
+	settings := map[string]bool{"config": true, "overrides": true}
+	for _, path := range provider.ConfigSettingPaths() {
+		settings[path] = true
+	}
+
+	fields := map[string]bool{}
+	policyType := reflect.TypeOf(policy{})
+	for i := 0; i < policyType.NumField(); i++ {
+		field := policyType.Field(i)
+		name := field.Tag.Get("json")
+		fields[name] = true
+		if field.Type.Kind() == reflect.Map && field.Type.Elem().Kind() == reflect.Struct {
+			for j := 0; j < field.Type.Elem().NumField(); j++ {
+				fields[name+"."+field.Type.Elem().Field(j).Tag.Get("json")] = true
+			}
+		}
+	}
+
+	for name := range fields {
+		if !settings[name] {
+			t.Errorf("policy field %s is not a provider setting", name)
+		}
+		if _, ok := unsupportedPolicyFields[strings.SplitN(name, ".", 2)[0]]; ok {
+			t.Errorf("policy field %s is also listed as unsupported", name)
+		}
+	}
+	for name := range settings {
+		if fields[name] {
+			continue
+		}
+		if _, ok := unsupportedPolicyFields[strings.SplitN(name, ".", 2)[0]]; !ok {
+			t.Errorf("provider setting %s is neither a policy field nor listed in unsupportedPolicyFields", name)
+		}
+	}
+	for name := range unsupportedPolicyFields {
+		if !settings[name] {
+			t.Errorf("unsupported field %s is not a provider setting", name)
+		}
+	}
+}
+
+func TestPolicyAllowedEnvs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"org_prefix": "acme", "env": "sandbox", "allowed_envs": ["dev", "prd"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadPolicy(path); err == nil || !strings.Contains(err.Error(), "sandbox") {
+		t.Fatalf("expected env outside allowed_envs to be rejected, got %v", err)
+	}
+
+	_, ts, _ := newTestServer(t, `{"org_prefix": "acme", "env": "dev", "allowed_envs": ["dev", "prd"]}`)
+	var got errorResponse
+	status := doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "s3", "overrides": {"env": "sandbox"}}`, &got)
+	if status != http.StatusUnprocessableEntity || got.Component != "env" {
+		t.Fatalf("expected env override outside allowed_envs to be rejected, got %d %+v", status, got)
+	}
+}
+
+func TestPolicyDisplaySettings(t *testing.T) {
+	_, ts, _ := newTestServer(t, `{
+  "org_prefix": "acme",
+  "project": "shop",
+  "env": "dev",
+  "region": "eu-west-1",
+  "region_map": {"eu-west-1": "ire"},
+  "display_recipe": ["proj", "env", "region"],
+  "display_style": "lower",
+  "display_expansions": {"shop": "Shop Front"}
+}`)
+	var got markResponse
+	status := doJSON(t, http.MethodPost, ts.URL+"/v1/mark", `{"resource": "route53_record", "qualifier": "api"}`, &got)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if got.Name != "acme-shop-dev-ire-r53r-api" {
+		t.Fatalf("expected region_map code in name, got %q", got.Name)
+	}
+	if got.DisplayName != "shop front dev eu west 1" {
+		t.Fatalf("unexpected display name %q", got.DisplayName)
+	}
+}
+
+func TestOpenAPIDocumentCoversRequestTypes(t *testing.T) {
+	_, ts, _ := newTestServer(t, testPolicy)
+
+	var doc struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string       `json:"required"`
+				Properties map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if status := doJSON(t, http.MethodGet, ts.URL+"/v1/openapi.json", "", &doc); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	for _, op := range operations {
+		if _, ok := doc.Paths[op.path][strings.ToLower(op.method)]; !ok {
+			t.Fatalf("missing %s %s", op.method, op.path)
+		}
+	}
+	mark := doc.Components.Schemas["MarkRequest"]
+	if len(mark.Required) != 1 || mark.Required[0] != "resource" {
+		t.Fatalf("unexpected required fields %v", mark.Required)
+	}
+	for _, property := range []string{"style_priority", "display_recipe", "display_style", "parent", "parent_mode"} {
+		if _, ok := mark.Properties[property]; !ok {
+			t.Fatalf("expected %s in MarkRequest", property)
+		}
+	}
+	if _, ok := doc.Components.Schemas["CatalogEntry"]; !ok {
+		t.Fatal("expected nested CatalogEntry schema")
+	}
+}
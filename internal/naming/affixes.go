@@ -0,0 +1,53 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FifoSuffix ends the names of FIFO queues and topics.
+const FifoSuffix = ".fifo"
+
+// ResourceAffix decorates the formatted names of one resource type. Prefix
+// and Suffix are added verbatim after the style is applied and count toward
+// the resource constraint. Fifo marks resources that accept FifoSuffix
+// through BuildInput.Fifo.
+type ResourceAffix struct {
+	Prefix string
+	Suffix string
+	Fifo   bool
+}
+
+func lookupResourceAffix(resourceKeys []string, affixes map[string]ResourceAffix) (ResourceAffix, bool) {
+	for _, key := range resourceKeys {
+		if affix, ok := affixes[key]; ok {
+			return affix, true
+		}
+	}
+	return ResourceAffix{}, false
+}
+
+// apply decorates name. It fails when fifo is set for a resource that has
+// no FIFO variant.
+func (a ResourceAffix) apply(resource, name string, fifo bool) (string, error) {
+	if fifo && !a.Fifo {
+		return "", fmt.Errorf("resource %q has no FIFO variant", resource)
+	}
+	name = a.Prefix + name + a.Suffix
+	if fifo {
+		name += FifoSuffix
+	}
+	return name, nil
+}
+
+// strip removes the decorations from name, reporting false when name does
+// not carry the prefix or suffix. The FIFO suffix is optional.
+func (a ResourceAffix) strip(name string) (string, bool) {
+	if a.Fifo {
+		name = strings.TrimSuffix(name, FifoSuffix)
+	}
+	if !strings.HasPrefix(name, a.Prefix) || !strings.HasSuffix(name, a.Suffix) || len(name) < len(a.Prefix)+len(a.Suffix) {
+		return "", false
+	}
+	return name[len(a.Prefix) : len(name)-len(a.Suffix)], true
+}
@@ -0,0 +1,108 @@
+package naming
+
+import (
+	"sort"
+	"strings"
+)
+
+// Acronym collision policies decide how resource keys that resolve to the
+// same acronym without being declared aliases are reported.
+const (
+	AcronymCollisionWarn  = "warn"
+	AcronymCollisionError = "error"
+	AcronymCollisionOff   = "off"
+)
+
+// AcronymCollision describes two unrelated resource keys sharing an acronym.
+type AcronymCollision struct {
+	Resource string
+	Other    string
+	Acronym  string
+}
+
+// IsValidAcronymCollisionPolicy reports whether policy is a supported acronym collision policy.
+func IsValidAcronymCollisionPolicy(policy string) bool {
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case "", AcronymCollisionWarn, AcronymCollisionError, AcronymCollisionOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// FindAcronymCollisions returns, for each key in resources, the other keys in
+// acronyms that resolve to the same acronym and are not aliases of it. Keys
+// are aliases when following aliases from both ends reaches the same key.
+// Other keys that alias each other are reported once, by their root.
+func FindAcronymCollisions(acronyms, aliases map[string]string, resources []string) []AcronymCollision {
+	byAcronym := map[string][]string{}
+	for key, acronym := range acronyms {
+		acronym = strings.ToLower(strings.TrimSpace(acronym))
+		if acronym == "" {
+			continue
+		}
+		byAcronym[acronym] = append(byAcronym[acronym], key)
+	}
+
+	seen := map[string]bool{}
+	collisions := []AcronymCollision{}
+	for _, resource := range resources {
+		acronym := strings.ToLower(strings.TrimSpace(acronyms[resource]))
+		if acronym == "" {
+			continue
+		}
+		for _, other := range byAcronym[acronym] {
+			otherRoot, resourceRoot := aliasRoot(other, aliases), aliasRoot(resource, aliases)
+			if other == resource || otherRoot == resourceRoot {
+				continue
+			}
+			if strings.ToLower(strings.TrimSpace(acronyms[otherRoot])) == acronym {
+				other = otherRoot
+			}
+			pair := resourceRoot + "\x00" + otherRoot
+			if resourceRoot > otherRoot {
+				pair = otherRoot + "\x00" + resourceRoot
+			}
+			if seen[pair] {
+				continue
+			}
+			seen[pair] = true
+			collisions = append(collisions, AcronymCollision{Resource: resource, Other: other, Acronym: acronym})
+		}
+	}
+
+	sort.Slice(collisions, func(i, j int) bool {
+		if collisions[i].Resource != collisions[j].Resource {
+			return collisions[i].Resource < collisions[j].Resource
+		}
+		return collisions[i].Other < collisions[j].Other
+	})
+	return collisions
+}
+
+// CanonicalResourceKey returns the key resource resolves to for cloud: the
+// last of its lookup candidates, with aliases followed to their root. Keys
+// that name the same resource, such as "google_storage_bucket" and "gcs" on
+// GCP, share a canonical key.
+func CanonicalResourceKey(cloud, resource string, aliases map[string]string) string {
+	candidates := resourceLookupCandidates(cloud, resource)
+	if len(candidates) == 0 {
+		return ""
+	}
+	return aliasRoot(candidates[len(candidates)-1], aliases)
+}
+
+// aliasRoot follows aliases from key until it reaches a key that is not an
+// alias. Cycles stop after every alias has been visited once.
+func aliasRoot(key string, aliases map[string]string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for i := 0; i < len(aliases); i++ {
+		target, ok := aliases[key]
+		target = strings.ToLower(strings.TrimSpace(target))
+		if !ok || target == "" || target == key {
+			break
+		}
+		key = target
+	}
+	return key
+}
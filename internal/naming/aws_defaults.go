@@ -1,7 +1,5 @@
 package naming
 
-import "regexp"
-
 func DefaultRegionMap() map[string]string {
 	return map[string]string{
 		"us-east-1":      "use1",
@@ -38,252 +36,30 @@ func DefaultRegionMap() map[string]string {
 		"sa-east-1":      "sae1",
 		"us-gov-west-1":  "usgw1",
 		"us-gov-east-1":  "usge1",
+		"global":         "glb",
 	}
 }
 
-func DefaultResourceAcronyms() map[string]string {
-	return map[string]string{
-		"role":                          "role",
-		"role_policy":                   "rlpl",
-		"iam_role":                      "role",
-		"iam_policy":                    "iamp",
-		"iam_user":                      "iamu",
-		"iam_group":                     "iamg",
-		"s3":                            "s3b",
-		"s3_bucket":                     "s3bk",
-		"s3_object":                     "s3ob",
-		"s3_access_point":               "s3ap",
-		"s3_table":                      "s3tb",
-		"s3_dir":                        "s3dr",
-		"sns":                           "sns",
-		"sqs":                           "sqs",
-		"ecs_cluster":                   "ecsc",
-		"ecs_service":                   "ecss",
-		"ecs_task":                      "ecst",
-		"eks":                           "eks",
-		"eks_cluster":                   "eksc",
-		"eks_node_group":                "ekng",
-		"msk_cluster":                   "mskc",
-		"vpc":                           "vpcn",
-		"subnet":                        "subn",
-		"igw":                           "igtw",
-		"nat_gw":                        "ngtw",
-		"sec_group":                     "scgp",
-		"nacl":                          "nacl",
-		"route_table":                   "rttb",
-		"elastic_ip":                    "elip",
-		"wafv2_web_acl":                 "wfac",
-		"wafv2_web_acl_rule":            "wfar",
-		"wafv2_ip_set":                  "wfis",
-		"lambda":                        "lmbd",
-		"api_gateway_rest_api":          "agra",
-		"api_gateway_model":             "agmd",
-		"api_gateway_v2":                "agv2",
-		"log_group":                     "logg",
-		"cloudwatch_log_group":          "cwlg",
-		"cloudwatch_alarm":              "cwal",
-		"eventbridge_bus":               "evbb",
-		"eventbridge_rule":              "evbr",
-		"step_function":                 "stfn",
-		"sfn":                           "stfn",
-		"dynamodb":                      "dydb",
-		"dynamodb_table":                "dybt",
-		"rds":                           "rds",
-		"rds_cluster":                   "rdsc",
-		"aurora_cluster":                "arcl",
-		"redshift":                      "rdsh",
-		"elasticache":                   "elch",
-		"opensearch":                    "opsr",
-		"elasticsearch":                 "elsr",
-		"ecr":                           "ecr",
-		"ecs":                           "ecs",
-		"ec2_instance":                  "ec2i",
-		"launch_template":               "lcht",
-		"autoscaling_group":             "asgr",
-		"alb":                           "albl",
-		"nlb":                           "nlbl",
-		"elb":                           "elbl",
-		"target_group":                  "tgpt",
-		"cloudfront":                    "clfr",
-		"route53_zone":                  "rt53",
-		"route53_record":                "r53r",
-		"acm_cert":                      "acmc",
-		"kms_key":                       "kmsk",
-		"secretsmanager_secret":         "smse",
-		"ssm_parameter":                 "ssmp",
-		"cloudtrail":                    "ctra",
-		"guardduty":                     "gdty",
-		"config_rule":                   "cfrl",
-		"efs":                           "efs",
-		"ebs":                           "ebs",
-		"athena":                        "athn",
-		"glue":                          "glue",
-		"sagemaker":                     "sgmk",
-		"codebuild":                     "cdbd",
-		"codepipeline":                  "cdpl",
-		"codedeploy":                    "cddp",
-		"cloudformation_stack":          "cfst",
-		"appsync":                       "apsy",
-		"snow_notification_integration": "snti",
-	}
-}
-
-func DefaultGlobalResources() map[string]bool {
-	return map[string]bool{
-		"role":           true,
-		"role_policy":    true,
-		"iam_role":       true,
-		"iam_policy":     true,
-		"iam_user":       true,
-		"iam_group":      true,
-		"cloudfront":     true,
-		"route53_zone":   true,
-		"route53_record": true,
-	}
-}
-
-func DefaultRegionalResources() map[string]bool {
-	regional := map[string]bool{}
-	for key := range DefaultResourceAcronyms() {
-		regional[key] = true
-	}
-	for key := range DefaultGlobalResources() {
-		delete(regional, key)
-	}
-	return regional
+// DefaultDisplayConstraints returns display-name constraints for AWS resources.
+// AWS resources rarely expose a separate display name, so the generic
+// DefaultDisplayConstraint applies to everything.
+func DefaultDisplayConstraints() map[string]ResourceConstraint {
+	return map[string]ResourceConstraint{}
 }
 
-func DefaultResourceStyleOverrides() map[string][]string {
-	return map[string][]string{
-		"s3":        {StyleDashed, StyleStraight},
-		"s3_bucket": {StyleDashed, StyleStraight},
+// DefaultResourceAliases maps AWS resource keys that intentionally share an
+// acronym to the key they alias.
+func DefaultResourceAliases() map[string]string {
+	return map[string]string{
+		"iam_role":       "role",
+		"sfn":            "step_function",
+		"sqs_queue":      "sqs",
+		"sns_topic":      "sns",
+		"security_group": "sec_group",
 	}
 }
 
-func DefaultResourceConstraints() map[string]ResourceConstraint {
-	return map[string]ResourceConstraint{
-		"s3": {
-			MinLen:              3,
-			MaxLen:              63,
-			Pattern:             regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`),
-			PatternDescription:  "lowercase letters, numbers, dots, and hyphens; must start and end with a letter or number",
-			ForbiddenPrefixes:   []string{"xn--", "sthree-", "amzn-s3-demo-"},
-			ForbiddenSuffixes:   []string{"-s3alias", "--ol-s3"},
-			ForbiddenSubstrings: []string{".."},
-			DisallowIPAddress:   true,
-		},
-		"s3_bucket": {
-			MinLen:              3,
-			MaxLen:              63,
-			Pattern:             regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`),
-			PatternDescription:  "lowercase letters, numbers, dots, and hyphens; must start and end with a letter or number",
-			ForbiddenPrefixes:   []string{"xn--", "sthree-", "amzn-s3-demo-"},
-			ForbiddenSuffixes:   []string{"-s3alias", "--ol-s3"},
-			ForbiddenSubstrings: []string{".."},
-			DisallowIPAddress:   true,
-		},
-		"role": {
-			MinLen:             1,
-			MaxLen:             64,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9+=,.@_-]+$`),
-			PatternDescription: "alphanumeric and the following: +=,.@_-",
-		},
-		"iam_role": {
-			MinLen:             1,
-			MaxLen:             64,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9+=,.@_-]+$`),
-			PatternDescription: "alphanumeric and the following: +=,.@_-",
-		},
-		"iam_user": {
-			MinLen:             1,
-			MaxLen:             64,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9+=,.@_-]+$`),
-			PatternDescription: "alphanumeric and the following: +=,.@_-",
-		},
-		"iam_group": {
-			MinLen:             1,
-			MaxLen:             128,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9+=,.@_-]+$`),
-			PatternDescription: "alphanumeric and the following: +=,.@_-",
-		},
-		"iam_policy": {
-			MinLen:             1,
-			MaxLen:             128,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9+=,.@_-]+$`),
-			PatternDescription: "alphanumeric and the following: +=,.@_-",
-		},
-		"role_policy": {
-			MinLen:             1,
-			MaxLen:             128,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9+=,.@_-]+$`),
-			PatternDescription: "alphanumeric and the following: +=,.@_-",
-		},
-		"sns": {
-			MinLen:             1,
-			MaxLen:             256,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.fifo)?$`),
-			PatternDescription: "letters, numbers, underscores, and hyphens; FIFO topics must end with .fifo",
-		},
-		"sns_topic": {
-			MinLen:             1,
-			MaxLen:             256,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.fifo)?$`),
-			PatternDescription: "letters, numbers, underscores, and hyphens; FIFO topics must end with .fifo",
-		},
-		"sqs": {
-			MinLen:             1,
-			MaxLen:             80,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.fifo)?$`),
-			PatternDescription: "letters, numbers, underscores, and hyphens; FIFO queues must end with .fifo",
-		},
-		"sqs_queue": {
-			MinLen:             1,
-			MaxLen:             80,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.fifo)?$`),
-			PatternDescription: "letters, numbers, underscores, and hyphens; FIFO queues must end with .fifo",
-		},
-		"lambda": {
-			MinLen:             1,
-			MaxLen:             64,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9-_]+$`),
-			PatternDescription: "letters, numbers, hyphens, and underscores",
-		},
-		"kms_alias": {
-			MinLen:             1,
-			MaxLen:             256,
-			Pattern:            regexp.MustCompile(`^alias/[a-zA-Z0-9/_-]+$`),
-			PatternDescription: "must begin with alias/ and contain only letters, numbers, slashes, underscores, and hyphens",
-			ForbiddenPrefixes:  []string{"alias/aws/"},
-		},
-		"log_group": {
-			MinLen:             1,
-			MaxLen:             512,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9_\-/.#]+$`),
-			PatternDescription: "letters, numbers, underscore, hyphen, slash, period, and #",
-			ForbiddenPrefixes:  []string{"aws/"},
-		},
-		"cloudwatch_log_group": {
-			MinLen:             1,
-			MaxLen:             512,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9_\-/.#]+$`),
-			PatternDescription: "letters, numbers, underscore, hyphen, slash, period, and #",
-			ForbiddenPrefixes:  []string{"aws/"},
-		},
-		"sec_group": {
-			MinLen:             1,
-			MaxLen:             255,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9 ._\-:/()#,@\[\]+=&;{}!$*]+$`),
-			PatternDescription: "letters, numbers, spaces, and ._-:/()#,@[]+=&;{}!$*",
-			ForbiddenPrefixes:  []string{"sg-"},
-			CaseInsensitive:    true,
-		},
-		"security_group": {
-			MinLen:             1,
-			MaxLen:             255,
-			Pattern:            regexp.MustCompile(`^[a-zA-Z0-9 ._\-:/()#,@\[\]+=&;{}!$*]+$`),
-			PatternDescription: "letters, numbers, spaces, and ._-:/()#,@[]+=&;{}!$*",
-			ForbiddenPrefixes:  []string{"sg-"},
-			CaseInsensitive:    true,
-		},
-	}
+// DefaultReservedWords lists words AWS reserves for its own resources.
+func DefaultReservedWords() []string {
+	return []string{"aws", "amazon", "amzn"}
 }
@@ -1,5 +1,7 @@
 package naming
 
+import "regexp"
+
 // DefaultAzureRegionMap maps Azure region names to short region codes.
 // Keys use Azure location names (for example "westeurope", "eastus2").
 func DefaultAzureRegionMap() map[string]string {
@@ -75,5 +77,21 @@ func DefaultAzureRegionMap() map[string]string {
 		"usgoviowa":          "ugi",
 		"usdodcentral":       "udc",
 		"usdodeast":          "ude",
+		"global":             "glb",
+		"europe":             "eu",
+		"unitedstates":       "us",
+		"asiapacific":        "apac",
+	}
+}
+
+// DefaultAzureDisplayConstraints returns display-name constraints for Azure
+// resources that expose a free-form display name next to their identifier.
+func DefaultAzureDisplayConstraints() map[string]ResourceConstraint {
+	return map[string]ResourceConstraint{
+		"azurerm_management_group": {
+			MaxLen:             90,
+			Pattern:            regexp.MustCompile(`^[^\x00-\x1f\x7f]*$`),
+			PatternDescription: "must not contain control characters",
+		},
 	}
 }
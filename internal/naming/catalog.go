@@ -0,0 +1,157 @@
+package naming
+
+import (
+	"sort"
+	"strings"
+)
+
+// Catalog tags. Every entry is also tagged with its uniqueness scope, such
+// as "global" or "parent".
+const (
+	CatalogTagRegional    = "regional"
+	CatalogTagZonal       = "zonal"
+	CatalogTagConstrained = "constrained"
+	CatalogTagStyled      = "styled"
+)
+
+// CatalogEntry describes one resource key known to a configuration.
+type CatalogEntry struct {
+	Resource           string
+	Acronym            string
+	Styles             []string
+	Regional           bool
+	Zonal              bool
+	Scope              string
+	MinLen             int
+	MaxLen             int
+	PatternDescription string
+	Tags               []string
+}
+
+// CatalogFilter narrows a catalog. Prefix matches the start of the resource
+// key; every tag in Tags must be present on an entry.
+type CatalogFilter struct {
+	Prefix string
+	Tags   []string
+}
+
+// Catalog lists every resource key the configuration knows about, sorted by
+// key. Maps left empty in cfg are filled from the cloud defaults, as in
+// BuildName. Styles lists the allowed styles, or every style when the
+// resource has no style restriction.
+func Catalog(cfg Config, filter CatalogFilter) ([]CatalogEntry, error) {
+	compiled, err := Compile(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.Catalog(filter), nil
+}
+
+// Catalog lists every resource key of the compiled configuration. See the
+// Catalog function.
+func (c *Compiled) Catalog(filter CatalogFilter) []CatalogEntry {
+	effective := c.cfg
+
+	keys := map[string]bool{}
+	for key := range effective.ResourceAcronyms {
+		keys[key] = true
+	}
+	for key := range effective.ResourceStyleOverrides {
+		keys[key] = true
+	}
+	for key := range effective.ResourceConstraints {
+		keys[key] = true
+	}
+	for key := range effective.RegionalResources {
+		keys[key] = true
+	}
+	for key := range effective.ResourceScopes {
+		keys[key] = true
+	}
+
+	prefixes := resourceLookupCandidates(effective.Cloud, filter.Prefix)
+	wantTags := make([]string, 0, len(filter.Tags))
+	for _, tag := range filter.Tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			wantTags = append(wantTags, tag)
+		}
+	}
+
+	entries := make([]CatalogEntry, 0, len(keys))
+	for key := range keys {
+		if len(prefixes) > 0 && !hasAnyPrefix(key, prefixes) {
+			continue
+		}
+		entry := catalogEntry(effective, key)
+		if !hasAllTags(entry.Tags, wantTags) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Resource < entries[j].Resource
+	})
+	return entries
+}
+
+func catalogEntry(cfg Config, key string) CatalogEntry {
+	lookupKeys := []string{key}
+	entry := CatalogEntry{
+		Resource: key,
+		Acronym:  cfg.ResourceAcronyms[key],
+		Regional: isRegionalResource(lookupKeys, cfg.RegionalResources),
+		Zonal:    isZonalResource(lookupKeys, cfg.ZonalResources),
+		Scope:    resourceScope(lookupKeys, cfg.ResourceScopes, cfg.RegionalResources),
+	}
+
+	tags := []string{entry.Scope}
+	if entry.Regional {
+		tags = append(tags, CatalogTagRegional)
+	}
+	if entry.Zonal {
+		tags = append(tags, CatalogTagZonal)
+	}
+	if styles, ok := lookupResourceStyles(lookupKeys, cfg.ResourceStyleOverrides); ok && len(styles) > 0 {
+		entry.Styles = append([]string{}, styles...)
+		tags = append(tags, CatalogTagStyled)
+	} else {
+		entry.Styles = DefaultStylePriority()
+	}
+	if _, constraint, ok := lookupResourceConstraint(lookupKeys, cfg.ResourceConstraints); ok {
+		entry.MinLen = constraint.MinLen
+		entry.MaxLen = constraint.MaxLen
+		entry.PatternDescription = constraint.PatternDescription
+		if entry.PatternDescription == "" && constraint.Pattern != nil {
+			entry.PatternDescription = constraint.Pattern.String()
+		}
+		tags = append(tags, CatalogTagConstrained)
+	}
+	sort.Strings(tags)
+	entry.Tags = tags
+	return entry
+}
+
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAllTags(tags, want []string) bool {
+	for _, tag := range want {
+		found := false
+		for _, have := range tags {
+			if have == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
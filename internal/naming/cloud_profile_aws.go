@@ -1,17 +1,53 @@
 package naming
 
-type awsCloudProfile struct{}
+import (
+	_ "embed"
+	"sync"
+)
 
-func (awsCloudProfile) Cloud() string {
+//go:embed aws_resource_definition.json
+var awsResourceDefinitionJSON []byte
+
+type awsCloudProfile struct {
+	defaultsOnce sync.Once
+	defaults     CloudDefaults
+	defaultsErr  error
+}
+
+func newAWSCloudProfile() CloudProfile {
+	return &awsCloudProfile{}
+}
+
+func (*awsCloudProfile) Cloud() string {
 	return CloudAWS
 }
 
-func (awsCloudProfile) Defaults() (CloudDefaults, error) {
-	return CloudDefaults{
-		RegionMap:              DefaultRegionMap(),
-		ResourceAcronyms:       DefaultResourceAcronyms(),
-		ResourceStyleOverrides: DefaultResourceStyleOverrides(),
-		ResourceConstraints:    DefaultResourceConstraints(),
-		RegionalResources:      DefaultRegionalResources(),
-	}, nil
+func (p *awsCloudProfile) Defaults() (CloudDefaults, error) {
+	p.defaultsOnce.Do(func() {
+		p.defaults, p.defaultsErr = loadAWSCloudDefaults()
+	})
+
+	if p.defaultsErr != nil {
+		return CloudDefaults{}, p.defaultsErr
+	}
+	return copyCloudDefaults(p.defaults), nil
+}
+
+func loadAWSCloudDefaults() (CloudDefaults, error) {
+	source, err := resourceDefinitionSourceFor(CloudAWS)
+	if err != nil {
+		return CloudDefaults{}, err
+	}
+	defaults, err := loadResourceDefinitions(source)
+	if err != nil {
+		return CloudDefaults{}, err
+	}
+
+	defaults.RegionMap = DefaultRegionMap()
+	defaults.MultiRegionLocations = DefaultMultiRegionLocations()
+	defaults.ZonalResources = DefaultZonalResources()
+	defaults.DisplayConstraints = DefaultDisplayConstraints()
+	defaults.ResourceAliases = DefaultResourceAliases()
+	defaults.ReservedWords = DefaultReservedWords()
+	return defaults, nil
 }
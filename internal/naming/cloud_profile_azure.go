@@ -2,9 +2,6 @@ package naming
 
 import (
 	_ "embed"
-	"encoding/json"
-	"fmt"
-	"regexp"
 	"strings"
 	"sync"
 	"unicode"
@@ -13,17 +10,6 @@ import (
 //go:embed azure_caf_resource_definition.json
 var azureCAFResourceDefinitionJSON []byte
 
-type azureCAFResourceDefinition struct {
-	Name            string `json:"name"`
-	MinLength       int    `json:"min_length"`
-	MaxLength       int    `json:"max_length"`
-	ValidationRegex string `json:"validation_regex"`
-	Scope           string `json:"scope"`
-	Slug            string `json:"slug"`
-	Dashes          bool   `json:"dashes"`
-	Lowercase       bool   `json:"lowercase"`
-}
-
 type azureCloudProfile struct {
 	defaultsOnce sync.Once
 	defaults     CloudDefaults
@@ -50,59 +36,34 @@ func (p *azureCloudProfile) Defaults() (CloudDefaults, error) {
 }
 
 func loadAzureCloudDefaults() (CloudDefaults, error) {
-	var definitions []azureCAFResourceDefinition
-	if err := json.Unmarshal(azureCAFResourceDefinitionJSON, &definitions); err != nil {
-		return CloudDefaults{}, fmt.Errorf("decode Azure CAF resource definitions: %w", err)
-	}
-
-	acronyms := make(map[string]string, len(definitions))
-	styleOverrides := make(map[string][]string, len(definitions))
-	constraints := make(map[string]ResourceConstraint, len(definitions))
-	regionalResources := make(map[string]bool, len(definitions))
-
-	for _, definition := range definitions {
-		name := strings.ToLower(strings.TrimSpace(definition.Name))
-		if name == "" {
-			continue
-		}
-
-		acronym := azureCAFOfficialResourceAcronym(definition.Slug)
-		if acronym != "" {
-			acronyms[name] = acronym
-		}
-		styleOverrides[name] = azureCAFStyleOverrides(definition.Lowercase, definition.Dashes)
-		regionalResources[name] = azureCAFIsRegionalScope(definition.Scope)
-		constraints[name] = azureCAFConstraint(definition)
-	}
-
-	return CloudDefaults{
-		RegionMap:              DefaultAzureRegionMap(),
-		ResourceAcronyms:       acronyms,
-		ResourceStyleOverrides: styleOverrides,
-		ResourceConstraints:    constraints,
-		RegionalResources:      regionalResources,
-	}, nil
-}
-
-func azureCAFConstraint(definition azureCAFResourceDefinition) ResourceConstraint {
-	constraint := ResourceConstraint{
-		MinLen: definition.MinLength,
-		MaxLen: definition.MaxLength,
+	source, err := resourceDefinitionSourceFor(CloudAzure)
+	if err != nil {
+		return CloudDefaults{}, err
 	}
-
-	regexValue := strings.TrimSpace(definition.ValidationRegex)
-	regexValue = strings.Trim(regexValue, `"`)
-	if regexValue == "" {
-		return constraint
+	defaults, err := loadResourceDefinitions(source)
+	if err != nil {
+		return CloudDefaults{}, err
 	}
 
-	constraint.PatternDescription = fmt.Sprintf("must match Azure CAF regex %q", regexValue)
-	pattern, err := regexp.Compile(regexValue)
-	if err != nil {
-		return constraint
+	defaults.RegionMap = DefaultAzureRegionMap()
+	defaults.MultiRegionLocations = DefaultAzureMultiRegionLocations()
+	defaults.ZonalResources = DefaultAzureZonalResources()
+	defaults.DisplayConstraints = DefaultAzureDisplayConstraints()
+	defaults.ResourceAliases = map[string]string{}
+	defaults.ReservedWords = DefaultAzureReservedWords()
+	return defaults, nil
+}
+
+// DefaultAzureReservedWords lists the trademarked words Azure rejects in
+// resource names with a ReservedResourceName error.
+func DefaultAzureReservedWords() []string {
+	return []string{
+		"access", "azure", "bing", "bizspark", "biztalk", "cortana", "directx", "dotnet",
+		"dynamics", "excel", "exchange", "forefront", "groove", "hololens", "hyperv",
+		"kinect", "login", "lync", "microsoft", "msdn", "o365", "office", "office365",
+		"onedrive", "onenote", "outlook", "powerpoint", "sharepoint", "skype", "visio",
+		"visualstudio", "windows", "xbox",
 	}
-	constraint.Pattern = pattern
-	return constraint
 }
 
 func azureCAFStyleOverrides(lowercase, dashes bool) []string {
@@ -122,10 +83,6 @@ func azureCAFStyleOverrides(lowercase, dashes bool) []string {
 	return styles
 }
 
-func azureCAFOfficialResourceAcronym(slug string) string {
-	return toLowerAlnum(slug)
-}
-
 func azureCAFIsRegionalScope(scope string) bool {
 	switch strings.ToLower(strings.TrimSpace(scope)) {
 	case "resourcegroup", "region", "location", "parent":
@@ -149,10 +106,17 @@ func toLowerAlnum(value string) string {
 func copyCloudDefaults(in CloudDefaults) CloudDefaults {
 	return CloudDefaults{
 		RegionMap:              copyStringMap(in.RegionMap),
+		MultiRegionLocations:   copyBoolMap(in.MultiRegionLocations),
 		ResourceAcronyms:       copyStringMap(in.ResourceAcronyms),
 		ResourceStyleOverrides: copyStringSliceMap(in.ResourceStyleOverrides),
 		ResourceConstraints:    copyConstraintMap(in.ResourceConstraints),
 		RegionalResources:      copyBoolMap(in.RegionalResources),
+		ZonalResources:         copyBoolMap(in.ZonalResources),
+		DisplayConstraints:     copyConstraintMap(in.DisplayConstraints),
+		ResourceScopes:         copyStringMap(in.ResourceScopes),
+		ResourceAliases:        copyStringMap(in.ResourceAliases),
+		ReservedWords:          append([]string{}, in.ReservedWords...),
+		ResourceAffixes:        copyAffixMap(in.ResourceAffixes),
 	}
 }
 
@@ -189,3 +153,11 @@ func copyConstraintMap(in map[string]ResourceConstraint) map[string]ResourceCons
 	}
 	return out
 }
+
+func copyAffixMap(in map[string]ResourceAffix) map[string]ResourceAffix {
+	out := make(map[string]ResourceAffix, len(in))
+	for key, value := range in {
+		out[key] = value
+	}
+	return out
+}
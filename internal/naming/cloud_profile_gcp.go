@@ -1,17 +1,53 @@
 package naming
 
-type gcpCloudProfile struct{}
+import (
+	_ "embed"
+	"sync"
+)
 
-func (gcpCloudProfile) Cloud() string {
+//go:embed gcp_resource_definition.json
+var gcpResourceDefinitionJSON []byte
+
+type gcpCloudProfile struct {
+	defaultsOnce sync.Once
+	defaults     CloudDefaults
+	defaultsErr  error
+}
+
+func newGCPCloudProfile() CloudProfile {
+	return &gcpCloudProfile{}
+}
+
+func (*gcpCloudProfile) Cloud() string {
 	return CloudGCP
 }
 
-func (gcpCloudProfile) Defaults() (CloudDefaults, error) {
-	return CloudDefaults{
-		RegionMap:              DefaultGCPRegionMap(),
-		ResourceAcronyms:       DefaultGCPResourceAcronyms(),
-		ResourceStyleOverrides: DefaultGCPResourceStyleOverrides(),
-		ResourceConstraints:    DefaultGCPResourceConstraints(),
-		RegionalResources:      DefaultGCPRegionalResources(),
-	}, nil
+func (p *gcpCloudProfile) Defaults() (CloudDefaults, error) {
+	p.defaultsOnce.Do(func() {
+		p.defaults, p.defaultsErr = loadGCPCloudDefaults()
+	})
+
+	if p.defaultsErr != nil {
+		return CloudDefaults{}, p.defaultsErr
+	}
+	return copyCloudDefaults(p.defaults), nil
+}
+
+func loadGCPCloudDefaults() (CloudDefaults, error) {
+	source, err := resourceDefinitionSourceFor(CloudGCP)
+	if err != nil {
+		return CloudDefaults{}, err
+	}
+	defaults, err := loadResourceDefinitions(source)
+	if err != nil {
+		return CloudDefaults{}, err
+	}
+
+	defaults.RegionMap = DefaultGCPRegionMap()
+	defaults.MultiRegionLocations = DefaultGCPMultiRegionLocations()
+	defaults.ZonalResources = DefaultGCPZonalResources()
+	defaults.DisplayConstraints = DefaultGCPDisplayConstraints()
+	defaults.ResourceAliases = DefaultGCPResourceAliases()
+	defaults.ReservedWords = DefaultGCPReservedWords()
+	return defaults, nil
 }
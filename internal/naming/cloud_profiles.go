@@ -10,10 +10,17 @@ const (
 
 type CloudDefaults struct {
 	RegionMap              map[string]string
+	MultiRegionLocations   map[string]bool
 	ResourceAcronyms       map[string]string
 	ResourceStyleOverrides map[string][]string
 	ResourceConstraints    map[string]ResourceConstraint
 	RegionalResources      map[string]bool
+	ZonalResources         map[string]bool
+	DisplayConstraints     map[string]ResourceConstraint
+	ResourceScopes         map[string]string
+	ResourceAliases        map[string]string
+	ReservedWords          []string
+	ResourceAffixes        map[string]ResourceAffix
 }
 
 type CloudProfile interface {
@@ -22,9 +29,9 @@ type CloudProfile interface {
 }
 
 var cloudProfiles = map[string]CloudProfile{
-	CloudAWS:   awsCloudProfile{},
+	CloudAWS:   newAWSCloudProfile(),
 	CloudAzure: newAzureCloudProfile(),
-	CloudGCP:   gcpCloudProfile{},
+	CloudGCP:   newGCPCloudProfile(),
 }
 
 func DefaultCloud() string {
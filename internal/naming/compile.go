@@ -0,0 +1,214 @@
+package naming
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Compiled is a Config prepared for building many names: cloud defaults are
+// resolved, resource keys are normalized, and region lookups are indexed.
+// Compile it once and reuse it; it is safe for concurrent use.
+type Compiled struct {
+	cfg               Config
+	regions           regionIndex
+	split             wordSplitter
+	displayExpansions map[string]string
+	enforcement       map[string]string
+	denied            denylist
+	hash              hasher
+}
+
+// Compile resolves cfg into a Compiled configuration. Maps left empty in cfg
+// are filled from the cloud defaults, as in BuildName.
+func Compile(cfg Config) (*Compiled, error) {
+	effective, err := FillCloudDefaults(cfg)
+	if err != nil {
+		return nil, err
+	}
+	split, err := newWordSplitter(effective.WordSplit)
+	if err != nil {
+		return nil, err
+	}
+	if !IsValidDisplayStyle(effective.DisplayStyle) {
+		return nil, fmt.Errorf("unsupported display_style %q; valid values are %q, %q, and %q", effective.DisplayStyle, DisplayStyleTitle, DisplayStyleSentence, DisplayStyleLower)
+	}
+	if strings.TrimSpace(effective.Env) != "" {
+		if err := checkAllowedEnv(effective, effective.Env); err != nil {
+			return nil, err
+		}
+	}
+	enforcement, err := resolveEnforcement(effective.Enforcement, effective.EnforcementOverrides)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := newHasher(effective.HashInputs, effective.HashAlgorithm, effective.HashEncoding, effective.HashLength)
+	if err != nil {
+		return nil, err
+	}
+	var reserved []string
+	if effective.DenyReservedWords {
+		defaults, err := DefaultCloudDefaults(effective.Cloud)
+		if err != nil {
+			return nil, err
+		}
+		reserved = defaults.ReservedWords
+	}
+
+	effective.ResourceAcronyms = normalizeResourceKeys(effective.ResourceAcronyms)
+	effective.ResourceStyleOverrides = normalizeResourceKeys(effective.ResourceStyleOverrides)
+	effective.ResourceConstraints = normalizeResourceKeys(effective.ResourceConstraints)
+	effective.RegionalResources = normalizeResourceKeys(effective.RegionalResources)
+	effective.ZonalResources = normalizeResourceKeys(effective.ZonalResources)
+	effective.DisplayConstraints = normalizeResourceKeys(effective.DisplayConstraints)
+	effective.ResourceRecipes = normalizeResourceKeys(effective.ResourceRecipes)
+	effective.ResourceScopes = normalizeResourceKeys(effective.ResourceScopes)
+	effective.ResourceAffixes = normalizeResourceKeys(effective.ResourceAffixes)
+
+	displayExpansions := DefaultDisplayExpansions()
+	for key, val := range effective.DisplayExpansions {
+		displayExpansions[strings.ToLower(strings.TrimSpace(key))] = val
+	}
+
+	compiled := &Compiled{
+		cfg:               effective,
+		regions:           newRegionIndex(effective.RegionMap, effective.MultiRegionLocations),
+		split:             split,
+		displayExpansions: displayExpansions,
+		enforcement:       enforcement,
+		denied:            newDenylist(effective.DeniedWords, reserved, effective.DeniedPatterns, split),
+		hash:              hash,
+	}
+	if effective.StrictRegions {
+		if unknown := compiled.unknownRegions(effective.Regions); len(unknown) > 0 {
+			return nil, unknown[0]
+		}
+	}
+	return compiled, nil
+}
+
+// Config returns the resolved configuration.
+func (c *Compiled) Config() Config {
+	return c.cfg
+}
+
+// RegionCode returns the region short code of the configuration. When
+// Config.Regions lists several regions it is their combined code.
+func (c *Compiled) RegionCode() string {
+	code, _ := c.resolveRegion(c.cfg.Regions)
+	return code
+}
+
+// normalizeResourceKeys returns a copy of values with lowercase, trimmed
+// keys. When two keys normalize to the same value, the one already in
+// normal form wins.
+func normalizeResourceKeys[V any](values map[string]V) map[string]V {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]V, len(values))
+	for key, val := range values {
+		if normalized := strings.ToLower(strings.TrimSpace(key)); normalized == key {
+			out[key] = val
+		}
+	}
+	for key, val := range values {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if normalized == "" {
+			continue
+		}
+		if _, ok := out[normalized]; !ok {
+			out[normalized] = val
+		}
+	}
+	return out
+}
+
+// regionIndex answers region code lookups without scanning the region map.
+// Keys match exactly, then lowercased, then with everything but letters and
+// digits removed.
+type regionIndex struct {
+	exact      map[string]string
+	normalized map[string]string
+	regions    map[string]string
+	keys       map[string]string
+	multi      map[string]bool
+}
+
+func newRegionIndex(regionMap map[string]string, multiRegions map[string]bool) regionIndex {
+	keys := make([]string, 0, len(regionMap))
+	for key := range regionMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	index := regionIndex{
+		exact:      make(map[string]string, len(regionMap)),
+		normalized: make(map[string]string, len(regionMap)),
+		regions:    make(map[string]string, len(regionMap)),
+		keys:       make(map[string]string, len(regionMap)),
+		multi:      make(map[string]bool, len(multiRegions)),
+	}
+	for key, multi := range multiRegions {
+		if normalized := normalizeRegionKey(key); normalized != "" && multi {
+			index.multi[normalized] = true
+		}
+	}
+	for _, key := range keys {
+		code := strings.TrimSpace(regionMap[key])
+		if code == "" {
+			continue
+		}
+		index.exact[key] = code
+		if normalized := normalizeRegionKey(key); normalized != "" {
+			if _, ok := index.normalized[normalized]; !ok {
+				index.normalized[normalized] = code
+				index.keys[normalized] = key
+			}
+		}
+		if _, ok := index.regions[strings.ToLower(code)]; !ok {
+			index.regions[strings.ToLower(code)] = key
+		}
+	}
+	return index
+}
+
+// code resolves the short code used for region. An explicit shortCode wins;
+// otherwise the region is looked up and used as-is when it is unknown.
+func (x regionIndex) code(region, shortCode string) string {
+	if code := strings.TrimSpace(shortCode); code != "" {
+		return code
+	}
+	region = strings.TrimSpace(region)
+	if region == "" {
+		return ""
+	}
+	if code := x.lookup(region); code != "" {
+		return code
+	}
+	return region
+}
+
+func (x regionIndex) lookup(region string) string {
+	region = strings.TrimSpace(region)
+	if region == "" {
+		return ""
+	}
+	if code, ok := x.exact[region]; ok {
+		return code
+	}
+	if code, ok := x.exact[strings.ToLower(region)]; ok {
+		return code
+	}
+	return x.normalized[normalizeRegionKey(region)]
+}
+
+// region returns the alphabetically first region that maps to code.
+func (x regionIndex) region(code string) string {
+	return x.regions[strings.ToLower(strings.TrimSpace(code))]
+}
+
+// isMulti reports whether region is a location that spans several regions.
+func (x regionIndex) isMulti(region string) bool {
+	return x.multi[normalizeRegionKey(region)]
+}
@@ -0,0 +1,80 @@
+package naming
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ComponentRule validates a single component value before it is formatted
+// into a name. Empty values are only rejected when Required is set.
+type ComponentRule struct {
+	MaxLen        int
+	Pattern       *regexp.Regexp
+	AllowedValues []string
+	Required      bool
+}
+
+// ComponentError reports a component that violates its ComponentRule.
+type ComponentError struct {
+	Component string
+	Value     string
+	Reason    string
+}
+
+func (e *ComponentError) Error() string {
+	return fmt.Sprintf("component %q value %q %s", e.Component, e.Value, e.Reason)
+}
+
+// validateComponentRules checks components against rules. Rule keys are
+// matched through canonicalComponentKey, so a rule for "project" applies to
+// the "proj" component; keys that are not built-in components match custom
+// components introduced via overrides.
+func validateComponentRules(components map[string]string, rules map[string]ComponentRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		rule := rules[key]
+		component := canonicalComponentKey(key)
+		value, ok := components[component]
+		if !ok {
+			value = components[strings.TrimSpace(key)]
+		}
+		value = strings.TrimSpace(value)
+
+		if value == "" {
+			if rule.Required {
+				return &ComponentError{Component: component, Value: value, Reason: "is required"}
+			}
+			continue
+		}
+		if rule.MaxLen > 0 && len(value) > rule.MaxLen {
+			return &ComponentError{Component: component, Value: value, Reason: fmt.Sprintf("exceeds %d characters", rule.MaxLen)}
+		}
+		if rule.Pattern != nil && !rule.Pattern.MatchString(value) {
+			return &ComponentError{Component: component, Value: value, Reason: fmt.Sprintf("must match %q", rule.Pattern.String())}
+		}
+		if len(rule.AllowedValues) > 0 && !containsFold(rule.AllowedValues, value) {
+			return &ComponentError{Component: component, Value: value, Reason: fmt.Sprintf("must be one of: %s", strings.Join(rule.AllowedValues, ", "))}
+		}
+	}
+	return nil
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), value) {
+			return true
+		}
+	}
+	return false
+}
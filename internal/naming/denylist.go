@@ -0,0 +1,116 @@
+package naming
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// denylist rejects names containing denied or reserved words. Names are
+// checked word by word: the parts of a name are split with the configured
+// word splitter and compared case-insensitively, so a denied word only
+// matches whole words and never the inside of a longer one.
+type denylist struct {
+	entries  []deniedEntry
+	patterns []deniedPattern
+}
+
+type deniedEntry struct {
+	word     string
+	words    []string
+	reserved bool
+}
+
+// deniedPattern keeps the configured pattern for messages and a copy
+// anchored to whole words and made case-insensitive for matching.
+type deniedPattern struct {
+	pattern *regexp.Regexp
+	word    *regexp.Regexp
+}
+
+func newDenylist(denied, reserved []string, patterns []*regexp.Regexp, split wordSplitter) denylist {
+	var list denylist
+	add := func(word string, isReserved bool) {
+		words := lowerWords(split.words(word))
+		if len(words) == 0 {
+			return
+		}
+		list.entries = append(list.entries, deniedEntry{word: strings.TrimSpace(word), words: words, reserved: isReserved})
+	}
+	for _, word := range denied {
+		add(word, false)
+	}
+	for _, word := range reserved {
+		add(word, true)
+	}
+	for _, pattern := range patterns {
+		if pattern == nil {
+			continue
+		}
+		word, err := regexp.Compile(`^(?i:` + pattern.String() + `)$`)
+		if err != nil {
+			continue
+		}
+		list.patterns = append(list.patterns, deniedPattern{pattern: pattern, word: word})
+	}
+	return list
+}
+
+// check returns the reason the words are denied, or "" when they are not.
+// cloud names the cloud reserved words come from.
+func (d denylist) check(words []string, cloud string) string {
+	words = lowerWords(words)
+	for _, entry := range d.entries {
+		if !containsWordRun(words, entry.words) {
+			continue
+		}
+		if entry.reserved {
+			return fmt.Sprintf("must not contain %q, which is reserved by %s", entry.word, cloud)
+		}
+		return fmt.Sprintf("must not contain denied word %q", entry.word)
+	}
+	for _, pattern := range d.patterns {
+		for _, word := range words {
+			if pattern.word.MatchString(word) {
+				return fmt.Sprintf("must not contain %q, which matches denied pattern %q", word, pattern.pattern.String())
+			}
+		}
+	}
+	return ""
+}
+
+// partWords splits name parts into words.
+func partWords(parts []string, split wordSplitter) []string {
+	words := []string{}
+	for _, part := range parts {
+		words = append(words, split.words(part)...)
+	}
+	return words
+}
+
+func lowerWords(words []string) []string {
+	out := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			out = append(out, word)
+		}
+	}
+	return out
+}
+
+// containsWordRun reports whether run appears in words as consecutive words.
+func containsWordRun(words, run []string) bool {
+	for i := 0; i+len(run) <= len(words); i++ {
+		matched := true
+		for j := range run {
+			if words[i+j] != run[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
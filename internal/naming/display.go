@@ -0,0 +1,297 @@
+package naming
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	DisplayStyleTitle    = "title"
+	DisplayStyleSentence = "sentence"
+	DisplayStyleLower    = "lower"
+)
+
+// displayGeoWords are the tokens used to split concatenated region names such
+// as "westeurope" or "southeastasia" into readable words.
+var displayGeoWords = sortedByLengthDesc([]string{
+	"north", "south", "east", "west", "central",
+	"northeast", "northwest", "southeast", "southwest",
+	"america", "asia", "africa", "europe", "australia", "india",
+	"us", "uk", "uae", "brazil", "canada", "chile", "china", "france",
+	"germany", "israel", "italy", "japan", "jio", "korea", "malaysia",
+	"mexico", "indonesia", "newzealand", "norway", "poland", "qatar",
+	"spain", "sweden", "switzerland", "taiwan", "austria", "belgium",
+	"gov", "dod", "virginia", "arizona", "texas", "iowa",
+})
+
+var displayDigitsRe = regexp.MustCompile(`[0-9]+|[^0-9]+`)
+
+// displayControlCharsRe is shared by every DefaultDisplayConstraint, which is
+// looked up for most names.
+var displayControlCharsRe = regexp.MustCompile(`^[^\x00-\x1f\x7f]*$`)
+
+func DefaultDisplayRecipe() []string {
+	return []string{"org", "proj", "env", "-", "qualifier"}
+}
+
+// DefaultDisplayExpansions maps lowercase words to the form used in display
+// names. Keys are matched per word after splitting a component value.
+func DefaultDisplayExpansions() map[string]string {
+	return map[string]string{
+		"aws":        "AWS",
+		"gcp":        "GCP",
+		"af":         "AF",
+		"ap":         "AP",
+		"ca":         "CA",
+		"cn":         "CN",
+		"eu":         "EU",
+		"il":         "IL",
+		"me":         "ME",
+		"sa":         "SA",
+		"uk":         "UK",
+		"us":         "US",
+		"uae":        "UAE",
+		"dod":        "DoD",
+		"newzealand": "New Zealand",
+		"acl":        "ACL",
+		"acm":        "ACM",
+		"aks":        "AKS",
+		"alb":        "ALB",
+		"api":        "API",
+		"dns":        "DNS",
+		"ebs":        "EBS",
+		"ec2":        "EC2",
+		"ecr":        "ECR",
+		"ecs":        "ECS",
+		"efs":        "EFS",
+		"eks":        "EKS",
+		"elb":        "ELB",
+		"gke":        "GKE",
+		"ha":         "HA",
+		"http":       "HTTP",
+		"https":      "HTTPS",
+		"iam":        "IAM",
+		"id":         "ID",
+		"ip":         "IP",
+		"kms":        "KMS",
+		"msk":        "MSK",
+		"nat":        "NAT",
+		"nlb":        "NLB",
+		"rds":        "RDS",
+		"s3":         "S3",
+		"sns":        "SNS",
+		"sql":        "SQL",
+		"sqs":        "SQS",
+		"ssm":        "SSM",
+		"url":        "URL",
+		"vm":         "VM",
+		"vpc":        "VPC",
+		"vpn":        "VPN",
+		"waf":        "WAF",
+		"wafv2":      "WAFv2",
+	}
+}
+
+// DefaultDisplayConstraint is applied to display names of resources without a
+// dedicated entry in CloudDefaults.DisplayConstraints. Display names are
+// free-form, so only control characters and overly long values are rejected.
+func DefaultDisplayConstraint() ResourceConstraint {
+	return ResourceConstraint{
+		MaxLen:             256,
+		Pattern:            displayControlCharsRe,
+		PatternDescription: "must not contain control characters",
+	}
+}
+
+// IsValidDisplayStyle reports whether style is a supported display style. An
+// empty value selects DisplayStyleTitle.
+func IsValidDisplayStyle(style string) bool {
+	style = normalizeStyle(style)
+	return style == "" || isValidDisplayStyle(style)
+}
+
+func isValidDisplayStyle(style string) bool {
+	switch style {
+	case DisplayStyleTitle, DisplayStyleSentence, DisplayStyleLower:
+		return true
+	default:
+		return false
+	}
+}
+
+type displayContext struct {
+	components  map[string]string
+	region      string
+	regions     regionIndex
+	resourceKey string
+	acronym     string
+	expansions  map[string]string
+	split       wordSplitter
+}
+
+func buildDisplayName(ctx displayContext, recipe []string, style string) (string, error) {
+	style = normalizeStyle(style)
+	if style == "" {
+		style = DisplayStyleTitle
+	}
+	if !isValidDisplayStyle(style) {
+		return "", fmt.Errorf("unsupported display style %q", style)
+	}
+
+	segments := []string{}
+	current := []string{}
+	flush := func() {
+		if len(current) > 0 {
+			segments = append(segments, strings.Join(current, " "))
+			current = []string{}
+		}
+	}
+
+	first := true
+	for _, item := range recipe {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !wordRe.MatchString(item) {
+			flush()
+			continue
+		}
+		for _, word := range displayComponentWords(ctx, item) {
+			current = append(current, displayWord(word, style, first, ctx.expansions))
+			first = false
+		}
+	}
+	flush()
+
+	return strings.Join(segments, " - "), nil
+}
+
+func displayComponentWords(ctx displayContext, item string) []string {
+	canonical := canonicalComponentKey(item)
+	val, ok := ctx.components[canonical]
+	if !ok {
+		val = ctx.components[item]
+	}
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return nil
+	}
+
+	switch canonical {
+	case "region":
+		region := strings.TrimSpace(ctx.region)
+		if region == "" || ctx.regions.lookup(region) != val {
+			region = ctx.regions.region(val)
+		}
+		if region != "" {
+			return regionDisplayWords(region)
+		}
+		if words := multiRegionDisplayWords(ctx.regions, val); words != nil {
+			return words
+		}
+	case "resource":
+		if val == ctx.acronym && ctx.resourceKey != "" {
+			return resourceDisplayWords(ctx.resourceKey)
+		}
+	}
+	return ctx.split.words(val)
+}
+
+func displayWord(word, style string, first bool, expansions map[string]string) string {
+	if expanded, ok := expansions[strings.ToLower(word)]; ok && expanded != "" {
+		if style == DisplayStyleLower {
+			return strings.ToLower(expanded)
+		}
+		return expanded
+	}
+	switch style {
+	case DisplayStyleTitle:
+		return titleWord(word)
+	case DisplayStyleSentence:
+		if first {
+			return titleWord(word)
+		}
+		return strings.ToLower(word)
+	default:
+		return strings.ToLower(word)
+	}
+}
+
+func regionDisplayWords(region string) []string {
+	words := []string{}
+	for _, segment := range splitWords(region) {
+		for _, run := range displayDigitsRe.FindAllString(strings.ToLower(segment), -1) {
+			if run[0] >= '0' && run[0] <= '9' {
+				words = append(words, run)
+				continue
+			}
+			words = append(words, splitGeoWords(run)...)
+		}
+	}
+	return words
+}
+
+func splitGeoWords(value string) []string {
+	words := []string{}
+	for value != "" {
+		matched := ""
+		for _, candidate := range displayGeoWords {
+			if strings.HasPrefix(value, candidate) {
+				matched = candidate
+				break
+			}
+		}
+		if matched == "" {
+			words = append(words, value)
+			break
+		}
+		words = append(words, matched)
+		value = value[len(matched):]
+	}
+	return words
+}
+
+func resourceDisplayWords(resourceKey string) []string {
+	key := strings.ToLower(strings.TrimSpace(resourceKey))
+	for _, prefix := range []string{"azurerm_", "google_", "aws_"} {
+		key = strings.TrimPrefix(key, prefix)
+	}
+	return splitWords(key)
+}
+
+func sortedByLengthDesc(values []string) []string {
+	out := make([]string, len(values))
+	copy(out, values)
+	sort.SliceStable(out, func(i, j int) bool {
+		if len(out[i]) != len(out[j]) {
+			return len(out[i]) > len(out[j])
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// multiRegionDisplayWords spells out a combined region code (euw1-euw4 ->
+// Europe West 1 / Europe West 4). It returns nil unless every code maps to a
+// region.
+func multiRegionDisplayWords(regions regionIndex, code string) []string {
+	codes := strings.Split(code, RegionSeparator)
+	if len(codes) < 2 {
+		return nil
+	}
+	words := []string{}
+	for i, part := range codes {
+		region := regions.region(part)
+		if region == "" {
+			return nil
+		}
+		if i > 0 {
+			words = append(words, "/")
+		}
+		words = append(words, regionDisplayWords(region)...)
+	}
+	return words
+}
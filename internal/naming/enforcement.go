@@ -0,0 +1,159 @@
+package naming
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Enforcement levels decide how a constraint violation is reported: as an
+// error that stops the name, as a warning next to the name, or not at all.
+const (
+	EnforcementError = "error"
+	EnforcementWarn  = "warn"
+	EnforcementOff   = "off"
+)
+
+// ConstraintStyle is the category of a name whose resource allows none of
+// the styles in the style priority, so the name falls back to the first
+// allowed style.
+const ConstraintStyle = "style"
+
+// EnforcementCategories returns the constraint categories an enforcement
+// level can be set for.
+func EnforcementCategories() []string {
+	return []string{ConstraintLength, ConstraintPattern, ConstraintForbidden, ConstraintStyle}
+}
+
+// IsValidEnforcement reports whether level is a supported enforcement level.
+func IsValidEnforcement(level string) bool {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "", EnforcementError, EnforcementWarn, EnforcementOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidEnforcementCategory reports whether category can carry its own
+// enforcement level.
+func IsValidEnforcementCategory(category string) bool {
+	return containsString(EnforcementCategories(), strings.ToLower(strings.TrimSpace(category)))
+}
+
+// resolveEnforcement returns the level of every category. A category
+// override wins over the configured level, which defaults to error. Style
+// fallbacks were never reported before enforcement levels existed, so the
+// style category stays off unless it is overridden.
+func resolveEnforcement(level string, overrides map[string]string) (map[string]string, error) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if !IsValidEnforcement(level) {
+		return nil, fmt.Errorf("unsupported enforcement %q", level)
+	}
+	if level == "" {
+		level = EnforcementError
+	}
+
+	levels := map[string]string{}
+	for _, category := range EnforcementCategories() {
+		levels[category] = level
+	}
+	levels[ConstraintStyle] = EnforcementOff
+	for category, override := range overrides {
+		category = strings.ToLower(strings.TrimSpace(category))
+		override = strings.ToLower(strings.TrimSpace(override))
+		if !IsValidEnforcementCategory(category) {
+			return nil, fmt.Errorf("unsupported enforcement category %q", category)
+		}
+		if override == "" {
+			continue
+		}
+		if !IsValidEnforcement(override) {
+			return nil, fmt.Errorf("unsupported enforcement %q for category %q", override, category)
+		}
+		levels[category] = override
+	}
+	return levels, nil
+}
+
+// enforceConstraints checks name against the denylist and the constraint of
+// its resource and applies the enforcement levels. parts are the components
+// the name was built from. A non-empty styleFallback is reported as a style
+// violation. It returns the violations whose level is warn, or the first
+// violation whose level is error.
+func (c *Compiled) enforceConstraints(resourceKeys []string, name string, parts []string, styleFallback string) ([]ConstraintError, error) {
+	var violations []ConstraintError
+	report := func(violation *ConstraintError) error {
+		switch c.enforcement[violation.Category] {
+		case EnforcementOff:
+			return nil
+		case EnforcementWarn:
+			violations = append(violations, *violation)
+			return nil
+		default:
+			return violation
+		}
+	}
+
+	resource := ""
+	if len(resourceKeys) > 0 {
+		resource = resourceKeys[0]
+	}
+	if styleFallback != "" {
+		if err := report(&ConstraintError{Resource: resource, Name: name, Category: ConstraintStyle, Reason: styleFallback}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each pass reports the first violation of a category not seen yet, so
+	// at most one violation per category is reported.
+	skip := map[string]bool{}
+	if reason := c.denied.check(partWords(parts, c.split), c.cfg.Cloud); reason != "" {
+		if err := report(&ConstraintError{Resource: resource, Name: name, Category: ConstraintForbidden, Reason: reason}); err != nil {
+			return nil, err
+		}
+		skip[ConstraintForbidden] = true
+	}
+	for {
+		err := validateResourceConstraints(resourceKeys, name, c.cfg.ResourceConstraints, skip)
+		if err == nil {
+			return violations, nil
+		}
+		var violation *ConstraintError
+		if !errors.As(err, &violation) {
+			return nil, err
+		}
+		if err := report(violation); err != nil {
+			return nil, err
+		}
+		skip[violation.Category] = true
+	}
+}
+
+// enforceDisplayConstraints checks the display name against the display
+// constraint of its resource under the enforcement levels of the name. A
+// display name never stops the name it belongs to, so violations at the
+// error level are reported like warnings, and off drops them.
+func (c *Compiled) enforceDisplayConstraints(resourceKeys []string, displayName string) []ConstraintError {
+	var violations []ConstraintError
+	skip := map[string]bool{}
+	for {
+		violation := validateDisplayConstraints(resourceKeys, displayName, c.cfg.DisplayConstraints, skip)
+		if violation == nil {
+			return violations
+		}
+		if c.enforcement[violation.Category] != EnforcementOff {
+			violations = append(violations, *violation)
+		}
+		skip[violation.Category] = true
+	}
+}
+
+func containsNormalizedStyle(styles []string, style string) bool {
+	for _, candidate := range styles {
+		if normalizeStyle(candidate) == style {
+			return true
+		}
+	}
+	return false
+}
@@ -47,218 +47,55 @@ func DefaultGCPRegionMap() map[string]string {
 		"me-central2":             "mec2",
 		"me-west1":                "mew1",
 		"africa-south1":           "afs1",
+		"us":                      "us",
+		"eu":                      "eu",
+		"asia":                    "asia",
+		"asia1":                   "asia1",
+		"eur4":                    "eur4",
+		"eur5":                    "eur5",
+		"eur7":                    "eur7",
+		"eur8":                    "eur8",
+		"nam4":                    "nam4",
+		"global":                  "glb",
 	}
 }
 
-func DefaultGCPResourceAcronyms() map[string]string {
-	return map[string]string{
-		"storage_bucket":                        "gcs",
-		"gcs_bucket":                            "gcs",
-		"gcs":                                   "gcs",
-		"compute_network":                       "vpc",
-		"compute_subnetwork":                    "snet",
-		"vpc":                                   "vpc",
-		"subnet":                                "snet",
-		"pubsub_topic":                          "pst",
-		"pubsub_subscription":                   "pss",
-		"pubsub_schema":                         "psch",
-		"pubsub_snapshot":                       "pssnp",
-		"service_account":                       "gsa",
-		"bigquery_dataset":                      "bqd",
-		"artifact_registry_repository":          "arr",
-		"cloud_run_v2_service":                  "crs2",
-		"cloud_run_service":                     "crs",
-		"compute_router":                        "crtr",
-		"compute_firewall":                      "cfwl",
-		"compute_address":                       "caddr",
-		"compute_global_address":                "gaddr",
-		"compute_route":                         "crte",
-		"compute_router_nat":                    "crnat",
-		"compute_vpn_gateway":                   "cvpng",
-		"compute_vpn_tunnel":                    "cvpnt",
-		"compute_ha_vpn_gateway":                "chvgw",
-		"compute_url_map":                       "cumap",
-		"compute_target_http_proxy":             "cthp",
-		"compute_target_https_proxy":            "cthps",
-		"compute_backend_service":               "cbksv",
-		"compute_region_backend_service":        "crbs",
-		"compute_instance_template":             "citpl",
-		"compute_instance_group_manager":        "cigm",
-		"compute_region_instance_group_manager": "crigm",
-		"compute_disk":                          "cdsk",
-		"compute_image":                         "cimg",
-		"compute_snapshot":                      "csnap",
-		"dns_managed_zone":                      "dnsz",
-		"secret_manager_secret":                 "sms",
-		"kms_key_ring":                          "kmr",
-		"kms_crypto_key":                        "kmk",
-		"sql_database_instance":                 "sqli",
-		"sql_instance":                          "sqli",
-		"container_cluster":                     "gkec",
-		"gke_cluster":                           "gkec",
-		"container_node_pool":                   "gkenp",
-		"gke_node_pool":                         "gkenp",
-		"vpc_access_connector":                  "vpcac",
-		"redis_instance":                        "rdis",
-		"memcache_instance":                     "memc",
-		"filestore_instance":                    "fils",
-		"spanner_instance":                      "spni",
-		"spanner_database":                      "spdb",
-		"cloudbuild_trigger":                    "cbt",
-		"eventarc_trigger":                      "evtr",
-		"cloud_scheduler_job":                   "schd",
-		"cloud_tasks_queue":                     "ctsk",
-		"workflows_workflow":                    "wflw",
-		"monitoring_notification_channel":       "mnc",
-		"logging_metric":                        "lgmt",
-		"logging_project_sink":                  "lpsk",
-	}
-}
-
-func DefaultGCPGlobalResources() map[string]bool {
-	return map[string]bool{
-		"storage_bucket":                  true,
-		"gcs_bucket":                      true,
-		"gcs":                             true,
-		"compute_network":                 true,
-		"vpc":                             true,
-		"pubsub_topic":                    true,
-		"pubsub_subscription":             true,
-		"service_account":                 true,
-		"compute_firewall":                true,
-		"compute_global_address":          true,
-		"compute_route":                   true,
-		"compute_url_map":                 true,
-		"compute_target_http_proxy":       true,
-		"compute_target_https_proxy":      true,
-		"compute_backend_service":         true,
-		"compute_image":                   true,
-		"dns_managed_zone":                true,
-		"secret_manager_secret":           true,
-		"cloudbuild_trigger":              true,
-		"monitoring_notification_channel": true,
-		"logging_metric":                  true,
-		"logging_project_sink":            true,
-		"pubsub_schema":                   true,
-		"pubsub_snapshot":                 true,
-	}
-}
-
-func DefaultGCPRegionalResources() map[string]bool {
-	regional := map[string]bool{}
-	for key := range DefaultGCPResourceAcronyms() {
-		regional[key] = true
-	}
-	for key := range DefaultGCPGlobalResources() {
-		delete(regional, key)
+func DefaultGCPDisplayConstraints() map[string]ResourceConstraint {
+	return map[string]ResourceConstraint{
+		"project": {
+			MinLen:             4,
+			MaxLen:             30,
+			Pattern:            regexp.MustCompile(`^[A-Za-z0-9'" !-]+$`),
+			PatternDescription: "letters, numbers, single or double quotes, hyphens, spaces, or exclamation points",
+		},
+		"service_account": {
+			MaxLen:             100,
+			Pattern:            regexp.MustCompile(`^[^\x00-\x1f\x7f]*$`),
+			PatternDescription: "must not contain control characters",
+		},
+		"monitoring_notification_channel": {
+			MaxLen:             512,
+			Pattern:            regexp.MustCompile(`^[^\x00-\x1f\x7f]*$`),
+			PatternDescription: "must not contain control characters",
+		},
 	}
-	return regional
 }
 
-func DefaultGCPResourceStyleOverrides() map[string][]string {
-	return map[string][]string{
-		"storage_bucket":       {StyleDashed, StyleUnderscore, StyleStraight},
-		"gcs_bucket":           {StyleDashed, StyleUnderscore, StyleStraight},
-		"gcs":                  {StyleDashed, StyleUnderscore, StyleStraight},
-		"compute_network":      {StyleDashed, StyleStraight},
-		"compute_subnetwork":   {StyleDashed, StyleStraight},
-		"vpc":                  {StyleDashed, StyleStraight},
-		"subnet":               {StyleDashed, StyleStraight},
-		"pubsub_topic":         {StyleDashed, StyleUnderscore, StyleStraight},
-		"pubsub_subscription":  {StyleDashed, StyleUnderscore, StyleStraight},
-		"service_account":      {StyleDashed, StyleStraight},
-		"bigquery_dataset":     {StyleStraight, StyleUnderscore},
-		"cloud_run_v2_service": {StyleDashed, StyleStraight},
-		"cloud_run_service":    {StyleDashed, StyleStraight},
+// DefaultGCPResourceAliases maps GCP resource keys that intentionally share an
+// acronym to the key they alias.
+func DefaultGCPResourceAliases() map[string]string {
+	return map[string]string{
+		"vpc":           "compute_network",
+		"subnet":        "compute_subnetwork",
+		"gke_cluster":   "container_cluster",
+		"gke_node_pool": "container_node_pool",
+		"gcs":           "storage_bucket",
+		"gcs_bucket":    "storage_bucket",
+		"sql_instance":  "sql_database_instance",
 	}
 }
 
-func DefaultGCPResourceConstraints() map[string]ResourceConstraint {
-	bucketConstraint := ResourceConstraint{
-		MinLen:              3,
-		MaxLen:              63,
-		Pattern:             regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*[a-z0-9]$`),
-		PatternDescription:  "lowercase letters, numbers, dots, underscores, and hyphens; must start and end with a letter or number",
-		ForbiddenPrefixes:   []string{"goog"},
-		ForbiddenSubstrings: []string{"google"},
-		// Cloud Storage also rejects close misspellings such as "g00gle".
-		ForbiddenPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`g[o0]{2}gle`),
-		},
-		DisallowIPAddress: true,
-	}
-
-	rfc1035Constraint := ResourceConstraint{
-		MinLen:             1,
-		MaxLen:             63,
-		Pattern:            regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`),
-		PatternDescription: "must start with a lowercase letter and contain only lowercase letters, numbers, or hyphens",
-	}
-
-	pubsubConstraint := ResourceConstraint{
-		MinLen:             3,
-		MaxLen:             255,
-		Pattern:            regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._~+%-]*$`),
-		PatternDescription: "must start with a letter and contain only letters, numbers, hyphens, underscores, periods, tildes, plus signs, or percent signs",
-		ForbiddenPrefixes:  []string{"goog"},
-	}
-
-	serviceAccountConstraint := ResourceConstraint{
-		MinLen:             6,
-		MaxLen:             30,
-		Pattern:            regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])$`),
-		PatternDescription: "must be 6-30 characters, start with a lowercase letter, and contain only lowercase letters, numbers, or hyphens",
-	}
-
-	bigQueryDatasetConstraint := ResourceConstraint{
-		MinLen:             1,
-		MaxLen:             1024,
-		Pattern:            regexp.MustCompile(`^[A-Za-z0-9_]+$`),
-		PatternDescription: "must contain only letters, numbers, or underscores",
-	}
-
-	// Cloud Run documents start/end/length rules for service IDs. The RFC1035-like
-	// character class here is the compatible subset we generate for dashed/straight styles.
-	cloudRunServiceConstraint := ResourceConstraint{
-		MinLen:             1,
-		MaxLen:             49,
-		Pattern:            regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`),
-		PatternDescription: "must start with a lowercase letter, end with a lowercase letter or number, and contain only lowercase letters, numbers, or hyphens",
-	}
-
-	return map[string]ResourceConstraint{
-		"storage_bucket":                        bucketConstraint,
-		"gcs_bucket":                            bucketConstraint,
-		"gcs":                                   bucketConstraint,
-		"compute_network":                       rfc1035Constraint,
-		"compute_subnetwork":                    rfc1035Constraint,
-		"vpc":                                   rfc1035Constraint,
-		"subnet":                                rfc1035Constraint,
-		"compute_router":                        rfc1035Constraint,
-		"compute_firewall":                      rfc1035Constraint,
-		"compute_address":                       rfc1035Constraint,
-		"compute_global_address":                rfc1035Constraint,
-		"compute_route":                         rfc1035Constraint,
-		"compute_router_nat":                    rfc1035Constraint,
-		"compute_vpn_gateway":                   rfc1035Constraint,
-		"compute_vpn_tunnel":                    rfc1035Constraint,
-		"compute_ha_vpn_gateway":                rfc1035Constraint,
-		"compute_url_map":                       rfc1035Constraint,
-		"compute_target_http_proxy":             rfc1035Constraint,
-		"compute_target_https_proxy":            rfc1035Constraint,
-		"compute_backend_service":               rfc1035Constraint,
-		"compute_region_backend_service":        rfc1035Constraint,
-		"compute_instance_template":             rfc1035Constraint,
-		"compute_instance_group_manager":        rfc1035Constraint,
-		"compute_region_instance_group_manager": rfc1035Constraint,
-		"compute_disk":                          rfc1035Constraint,
-		"compute_image":                         rfc1035Constraint,
-		"compute_snapshot":                      rfc1035Constraint,
-		"pubsub_topic":                          pubsubConstraint,
-		"pubsub_subscription":                   pubsubConstraint,
-		"service_account":                       serviceAccountConstraint,
-		"bigquery_dataset":                      bigQueryDatasetConstraint,
-		"cloud_run_v2_service":                  cloudRunServiceConstraint,
-		"cloud_run_service":                     cloudRunServiceConstraint,
-	}
+// DefaultGCPReservedWords lists words Google reserves in resource names.
+func DefaultGCPReservedWords() []string {
+	return []string{"google", "goog"}
 }
@@ -0,0 +1,233 @@
+package naming
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/big"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// HashComponent is the recipe item replaced by a short, deterministic hash
+// of the inputs listed in Config.HashInputs.
+const HashComponent = "hash"
+
+// HashInputName is the hash input that stands for the whole name without
+// the hash, formatted in the chosen style. It is the default input.
+const HashInputName = "name"
+
+// Hash algorithms.
+const (
+	HashAlgorithmSHA256 = "sha256"
+	HashAlgorithmSHA1   = "sha1"
+	HashAlgorithmMD5    = "md5"
+)
+
+// Hash encodings. Both produce lowercase letters and digits.
+const (
+	HashEncodingHex    = "hex"
+	HashEncodingBase32 = "base32"
+)
+
+// Hash lengths, in characters.
+const (
+	DefaultHashLength = 6
+	MinHashLength     = 4
+	MaxHashLength     = 16
+)
+
+const (
+	hexAlphabet    = "0123456789abcdef"
+	base32Alphabet = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+type hasher struct {
+	inputs    []string
+	algorithm string
+	encoding  string
+	length    int
+}
+
+// newHasher validates the hash settings. Empty settings select the
+// defaults: the whole name, sha256, hex, and DefaultHashLength characters.
+func newHasher(inputs []string, algorithm, encoding string, length int) (hasher, error) {
+	h := hasher{
+		algorithm: strings.ToLower(strings.TrimSpace(algorithm)),
+		encoding:  strings.ToLower(strings.TrimSpace(encoding)),
+		length:    length,
+	}
+	for _, input := range inputs {
+		if key := canonicalComponentKey(input); key != "" {
+			h.inputs = append(h.inputs, key)
+		}
+	}
+	if len(h.inputs) == 0 {
+		h.inputs = []string{HashInputName}
+	}
+	if h.algorithm == "" {
+		h.algorithm = HashAlgorithmSHA256
+	}
+	if h.encoding == "" {
+		h.encoding = HashEncodingHex
+	}
+	if h.length == 0 {
+		h.length = DefaultHashLength
+	}
+
+	if !IsValidHashAlgorithm(h.algorithm) {
+		return hasher{}, fmt.Errorf("unsupported hash algorithm %q; valid values are %q, %q, and %q", algorithm, HashAlgorithmSHA256, HashAlgorithmSHA1, HashAlgorithmMD5)
+	}
+	if !IsValidHashEncoding(h.encoding) {
+		return hasher{}, fmt.Errorf("unsupported hash encoding %q; valid values are %q and %q", encoding, HashEncodingHex, HashEncodingBase32)
+	}
+	if h.length < MinHashLength || h.length > MaxHashLength {
+		return hasher{}, fmt.Errorf("hash length must be between %d and %d, got %d", MinHashLength, MaxHashLength, length)
+	}
+	for _, input := range h.inputs {
+		if input == HashComponent {
+			return hasher{}, fmt.Errorf("hash inputs must not include %q", HashComponent)
+		}
+	}
+	return h, nil
+}
+
+// IsValidHashAlgorithm reports whether algorithm is a supported hash
+// algorithm. An empty value selects HashAlgorithmSHA256.
+func IsValidHashAlgorithm(algorithm string) bool {
+	switch strings.ToLower(strings.TrimSpace(algorithm)) {
+	case "", HashAlgorithmSHA256, HashAlgorithmSHA1, HashAlgorithmMD5:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidHashEncoding reports whether encoding is a supported hash encoding.
+// An empty value selects HashEncodingHex.
+func IsValidHashEncoding(encoding string) bool {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", HashEncodingHex, HashEncodingBase32:
+		return true
+	default:
+		return false
+	}
+}
+
+// compute hashes the inputs, joined with "/". name is the formatted name
+// without the hash. The digest is encoded with the characters of the
+// encoding that pattern accepts; when it accepts all of them the result is
+// a prefix of the usual hex or base32 encoding.
+func (h hasher) compute(components map[string]string, name string, pattern *regexp.Regexp) (string, error) {
+	values := make([]string, 0, len(h.inputs))
+	for _, input := range h.inputs {
+		if input == HashInputName {
+			values = append(values, name)
+			continue
+		}
+		values = append(values, components[input])
+	}
+
+	var digest hash.Hash
+	switch h.algorithm {
+	case HashAlgorithmSHA1:
+		digest = sha1.New()
+	case HashAlgorithmMD5:
+		digest = md5.New()
+	default:
+		digest = sha256.New()
+	}
+	digest.Write([]byte(strings.Join(values, "/")))
+	sum := digest.Sum(nil)
+
+	alphabet := hexAlphabet
+	if h.encoding == HashEncodingBase32 {
+		alphabet = base32Alphabet
+	}
+	allowed := allowedHashAlphabet(alphabet, pattern)
+	if len(allowed) < 2 {
+		return "", fmt.Errorf("the name pattern %q leaves too few %s characters for the hash", pattern.String(), h.encoding)
+	}
+
+	if allowed == alphabet {
+		encoded := hex.EncodeToString(sum)
+		if h.encoding == HashEncodingBase32 {
+			encoded = strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(sum))
+		}
+		return encoded[:h.length], nil
+	}
+
+	n := new(big.Int).SetBytes(sum)
+	base := big.NewInt(int64(len(allowed)))
+	digit := new(big.Int)
+	out := make([]byte, 0, h.length)
+	for len(out) < h.length {
+		n.DivMod(n, base, digit)
+		out = append(out, allowed[digit.Int64()])
+	}
+	return string(out), nil
+}
+
+// allowedHashAlphabet returns the characters of alphabet that pattern
+// accepts anywhere in a name. A letter counts when either case is
+// accepted, since the naming style decides its case.
+func allowedHashAlphabet(alphabet string, pattern *regexp.Regexp) string {
+	if pattern == nil {
+		return alphabet
+	}
+	parsed, err := syntax.Parse(pattern.String(), syntax.Perl)
+	if err != nil {
+		return alphabet
+	}
+	var ranges []rune
+	if !collectPatternRanges(parsed, &ranges) {
+		return alphabet
+	}
+
+	var out strings.Builder
+	for _, r := range alphabet {
+		if inRuneRanges(ranges, r) || inRuneRanges(ranges, unicode.ToUpper(r)) {
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+// collectPatternRanges appends the rune ranges re can match to ranges, as
+// pairs of bounds. It reports false when re matches any character.
+func collectPatternRanges(re *syntax.Regexp, ranges *[]rune) bool {
+	switch re.Op {
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return false
+	case syntax.OpCharClass:
+		*ranges = append(*ranges, re.Rune...)
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			*ranges = append(*ranges, r, r)
+			if re.Flags&syntax.FoldCase != 0 {
+				*ranges = append(*ranges, unicode.ToUpper(r), unicode.ToUpper(r), unicode.ToLower(r), unicode.ToLower(r))
+			}
+		}
+	}
+	for _, sub := range re.Sub {
+		if !collectPatternRanges(sub, ranges) {
+			return false
+		}
+	}
+	return true
+}
+
+func inRuneRanges(ranges []rune, r rune) bool {
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i] <= r && r <= ranges[i+1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package naming
+
+import (
+	"sort"
+	"strings"
+)
+
+// RegionSeparator joins the region codes of a name that spans several
+// regions (euw1-euw4).
+const RegionSeparator = "-"
+
+// DefaultMultiRegionLocations lists the AWS locations that span several
+// regions.
+func DefaultMultiRegionLocations() map[string]bool {
+	return map[string]bool{
+		"global": true,
+	}
+}
+
+// DefaultGCPMultiRegionLocations lists the GCP multi-region and predefined
+// dual-region locations.
+func DefaultGCPMultiRegionLocations() map[string]bool {
+	return map[string]bool{
+		"global": true,
+		"us":     true,
+		"eu":     true,
+		"asia":   true,
+		"asia1":  true,
+		"eur4":   true,
+		"eur5":   true,
+		"eur7":   true,
+		"eur8":   true,
+		"nam4":   true,
+	}
+}
+
+// DefaultAzureMultiRegionLocations lists the Azure locations that span
+// several regions.
+func DefaultAzureMultiRegionLocations() map[string]bool {
+	return map[string]bool{
+		"global":       true,
+		"europe":       true,
+		"unitedstates": true,
+		"asiapacific":  true,
+	}
+}
+
+// resolveRegion returns the region code of a name and whether the name spans
+// several regions. With more than one region the codes of the distinct
+// regions are sorted and joined with RegionSeparator, unless RegionShortCode
+// is set, so the order regions are listed in does not change the name. A
+// single region is multi-region when it is one of the multi-region locations.
+func (c *Compiled) resolveRegion(regions []string) (string, bool) {
+	effective := c.cfg
+	var first string
+	codes := make([]string, 0, len(regions))
+	seen := map[string]bool{}
+	for _, region := range regions {
+		code := c.regions.code(region, "")
+		if code == "" || seen[strings.ToLower(code)] {
+			continue
+		}
+		if len(codes) == 0 {
+			first = region
+		}
+		seen[strings.ToLower(code)] = true
+		codes = append(codes, code)
+	}
+
+	switch len(codes) {
+	case 0:
+		return c.regions.code(effective.Region, effective.RegionShortCode), c.regions.isMulti(effective.Region)
+	case 1:
+		return c.regions.code(first, effective.RegionShortCode), c.regions.isMulti(first)
+	}
+	if code := strings.TrimSpace(effective.RegionShortCode); code != "" {
+		return code, true
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return strings.ToLower(codes[i]) < strings.ToLower(codes[j])
+	})
+	return strings.Join(codes, RegionSeparator), true
+}
@@ -22,14 +22,22 @@ var (
 	wordRe = regexp.MustCompile(`[A-Za-z0-9]+`)
 )
 
+// Config is exposed as sigil.Config. Its fields, and those of the other types
+// pkg/sigil aliases, are frozen by pkg/sigil/compat_test.go: add fields, but
+// do not rename, remove, or retype them.
 type Config struct {
 	Cloud                            string
 	OrgPrefix                        string
 	Project                          string
 	Env                              string
+	EnvMap                           map[string]string
+	AllowedEnvs                      []string
 	Region                           string
+	Regions                          []string
 	RegionShortCode                  string
 	RegionMap                        map[string]string
+	MultiRegionLocations             map[string]bool
+	StrictRegions                    bool
 	Recipe                           []string
 	StylePriority                    []string
 	ResourceAcronyms                 map[string]string
@@ -37,23 +45,72 @@ type Config struct {
 	ResourceConstraints              map[string]ResourceConstraint
 	IgnoreRegionForRegionalResources bool
 	RegionalResources                map[string]bool
+	Zone                             string
+	ZonalResources                   map[string]bool
+	DisplayRecipe                    []string
+	DisplayStyle                     string
+	DisplayExpansions                map[string]string
+	DisplayConstraints               map[string]ResourceConstraint
+	ComponentRules                   map[string]ComponentRule
+	ResourceRecipes                  map[string][]string
+	WordSplit                        string
+	Transliteration                  string
+	ResourceScopes                   map[string]string
+	Enforcement                      string
+	EnforcementOverrides             map[string]string
+	DeniedWords                      []string
+	DeniedPatterns                   []*regexp.Regexp
+	DenyReservedWords                bool
+	HashInputs                       []string
+	HashAlgorithm                    string
+	HashEncoding                     string
+	HashLength                       int
+	Account                          string
+	Subscription                     string
+	GCPProject                       string
+	AccountMap                       map[string]string
+	ResourceAffixes                  map[string]ResourceAffix
 }
 
 type BuildInput struct {
 	Resource      string
 	Qualifier     string
+	Zone          string
 	Overrides     map[string]string
 	Recipe        []string
 	StylePriority []string
+	DisplayRecipe []string
+	DisplayStyle  string
+	ScopeKey      string
+	Parent        map[string]string
+	ParentMode    string
+	// Fifo appends FifoSuffix for resources with a FIFO variant.
+	Fifo bool
+	// Regions replaces Config.Regions for names that span several regions.
+	Regions []string
 }
 
 type BuildResult struct {
 	Name            string
+	DisplayName     string
 	Style           string
 	Components      map[string]string
 	Parts           []string
 	RegionCode      string
+	ZoneCode        string
 	ResourceAcronym string
+	RecipeSource    string
+	Warnings        []string
+	Scope           string
+	ScopeKey        string
+	ParentMode      string
+	// Violations lists the constraint violations whose enforcement level is
+	// warn. The name is still built.
+	Violations []ConstraintError
+	// UnknownRegions lists the regions of BuildInput.Regions missing from
+	// the region map, which were used as-is. Unknown configured regions are
+	// reported once by Compiled.UnknownRegions instead.
+	UnknownRegions []UnknownRegionError
 }
 
 type ResourceConstraint struct {
@@ -69,6 +126,14 @@ type ResourceConstraint struct {
 	CaseInsensitive     bool
 }
 
+// Recipe sources reported in BuildResult.RecipeSource, from highest to lowest precedence.
+const (
+	RecipeSourceMark     = "mark"
+	RecipeSourceResource = "resource"
+	RecipeSourceProvider = "provider"
+	RecipeSourceDefault  = "default"
+)
+
 func DefaultRecipe() []string {
 	return []string{"org", "proj", "env", "region", "resource", "qualifier"}
 }
@@ -77,38 +142,30 @@ func DefaultStylePriority() []string {
 	return []string{StyleDashed, StylePascal, StylePascalDashed, StyleCamel, StyleStraight, StyleUnderscore}
 }
 
+// BuildName compiles cfg and builds one name. Callers building many names
+// from one configuration should Compile it once instead.
 func BuildName(cfg Config, in BuildInput) (BuildResult, error) {
-	effective := cfg
-	if len(effective.RegionMap) == 0 || len(effective.ResourceAcronyms) == 0 || len(effective.ResourceStyleOverrides) == 0 || len(effective.ResourceConstraints) == 0 || len(effective.RegionalResources) == 0 {
-		defaults, err := DefaultCloudDefaults(effective.Cloud)
-		if err != nil {
-			return BuildResult{}, err
-		}
-		if len(effective.RegionMap) == 0 {
-			effective.RegionMap = defaults.RegionMap
-		}
-		if len(effective.ResourceAcronyms) == 0 {
-			effective.ResourceAcronyms = defaults.ResourceAcronyms
-		}
-		if len(effective.ResourceStyleOverrides) == 0 {
-			effective.ResourceStyleOverrides = defaults.ResourceStyleOverrides
-		}
-		if len(effective.ResourceConstraints) == 0 {
-			effective.ResourceConstraints = defaults.ResourceConstraints
-		}
-		if len(effective.RegionalResources) == 0 {
-			effective.RegionalResources = defaults.RegionalResources
-		}
+	compiled, err := Compile(cfg)
+	if err != nil {
+		return BuildResult{}, err
 	}
+	return compiled.BuildName(in)
+}
 
-	regionCode := strings.TrimSpace(effective.RegionShortCode)
-	region := strings.TrimSpace(effective.Region)
-	if regionCode == "" && region != "" {
-		regionCode = lookupRegionCode(effective.RegionMap, region)
-		if regionCode == "" {
-			regionCode = region
-		}
+// BuildName builds one name from the compiled configuration.
+func (c *Compiled) BuildName(in BuildInput) (BuildResult, error) {
+	return c.buildName(in, true)
+}
+
+// buildName builds one name. Component rules and constraints are only
+// checked when validate is set.
+func (c *Compiled) buildName(in BuildInput, validate bool) (BuildResult, error) {
+	effective := c.cfg
+	regions := in.Regions
+	if len(regions) == 0 {
+		regions = effective.Regions
 	}
+	regionCode, multiRegion := c.resolveRegion(regions)
 
 	resourceKey := strings.ToLower(strings.TrimSpace(in.Resource))
 	resourceLookupKeys := resourceLookupCandidates(effective.Cloud, resourceKey)
@@ -119,24 +176,57 @@ func BuildName(cfg Config, in BuildInput) (BuildResult, error) {
 		}
 	}
 
-	components := map[string]string{
-		"org":       strings.TrimSpace(effective.OrgPrefix),
-		"proj":      strings.TrimSpace(effective.Project),
-		"env":       strings.TrimSpace(effective.Env),
-		"region":    strings.TrimSpace(regionCode),
-		"resource":  strings.TrimSpace(resourceAcronym),
-		"qualifier": strings.TrimSpace(in.Qualifier),
+	zone := strings.TrimSpace(in.Zone)
+	explicitZone := zone != ""
+	if !explicitZone {
+		zone = strings.TrimSpace(effective.Zone)
 	}
+	zoneCode := zoneShortCode(effective.Cloud, zone, c.regions)
 
-	if effective.IgnoreRegionForRegionalResources && isRegionalResource(resourceLookupKeys, effective.RegionalResources) {
+	components := map[string]string{
+		"org":          strings.TrimSpace(effective.OrgPrefix),
+		"proj":         strings.TrimSpace(effective.Project),
+		"env":          EnvCode(effective.EnvMap, effective.Env),
+		"env_raw":      strings.TrimSpace(effective.Env),
+		"region":       strings.TrimSpace(regionCode),
+		"zone":         zoneCode,
+		"resource":     strings.TrimSpace(resourceAcronym),
+		"qualifier":    strings.TrimSpace(in.Qualifier),
+		"account":      strings.TrimSpace(effective.Account),
+		"subscription": strings.TrimSpace(effective.Subscription),
+		"gcp_project":  strings.TrimSpace(effective.GCPProject),
+	}
+
+	// Names spanning several regions keep the region, since it tells them
+	// apart from the single-region names of the same resource.
+	if effective.IgnoreRegionForRegionalResources && !multiRegion && isRegionalResource(resourceLookupKeys, effective.RegionalResources) {
 		components["region"] = ""
 	}
+	// A provider-level zone only applies to zonal resources; a zone set on the
+	// request is always honored.
+	if !explicitZone && !isZonalResource(resourceLookupKeys, effective.ZonalResources) {
+		components["zone"] = ""
+	}
+
+	scope := resourceScope(resourceLookupKeys, effective.ResourceScopes, effective.RegionalResources)
+	parent := canonicalParent(in.Parent)
+	parentMode, err := resolveParentMode(in.ParentMode, scope)
+	if err != nil {
+		return BuildResult{}, err
+	}
+	if len(parent) == 0 {
+		parentMode = ""
+	}
+	if parentMode == ParentModeReuse {
+		inheritParentComponents(components, parent)
+	}
 
 	overrides := in.Overrides
 	if overrides == nil {
 		overrides = map[string]string{}
 	}
 
+	envOverride, hasEnvOverride, hasEnvRawOverride := "", false, false
 	for key, val := range overrides {
 		key = strings.TrimSpace(key)
 		if key == "" {
@@ -148,24 +238,92 @@ func BuildName(cfg Config, in BuildInput) (BuildResult, error) {
 		} else {
 			components[key] = strings.TrimSpace(val)
 		}
+		switch canonical {
+		case "env":
+			envOverride, hasEnvOverride = strings.TrimSpace(val), true
+		case "env_raw":
+			hasEnvRawOverride = true
+		}
+	}
+	// An env passed per name is checked and mapped like the configured one.
+	if hasEnvOverride {
+		if err := checkAllowedEnv(effective, envOverride); err != nil {
+			return BuildResult{}, err
+		}
+		components["env"] = EnvCode(effective.EnvMap, envOverride)
+		if !hasEnvRawOverride {
+			components["env_raw"] = envOverride
+		}
+	}
+	// Account IDs are shortened after overrides, so an ID passed per name is
+	// mapped like the configured one.
+	for _, key := range accountComponents {
+		components[key] = AccountCode(effective.AccountMap, components[key])
+	}
+	warnings, err := transliterateComponents(components, effective.Transliteration)
+	if err != nil {
+		return BuildResult{}, err
+	}
+	// Unknown regions are used as-is, so a typo ends up in the name unless
+	// StrictRegions rejects it.
+	var unknownRegions []UnknownRegionError
+	if len(in.Regions) > 0 {
+		for _, unknown := range c.unknownRegions(in.Regions) {
+			if effective.StrictRegions {
+				return BuildResult{}, unknown
+			}
+			unknownRegions = append(unknownRegions, *unknown)
+		}
+	}
+	droppedComponents := map[string]bool{}
+	if parentMode == ParentModeDrop {
+		droppedComponents = sharedParentComponents(components, parent)
 	}
-	regionCode = components["region"]
 
-	recipe := effective.Recipe
-	if len(in.Recipe) > 0 {
-		recipe = in.Recipe
+	scopeKey := strings.TrimSpace(in.ScopeKey)
+	if scopeKey == "" && scope == ScopeParent && len(parent) > 0 {
+		scopeKey = parentScopeKey(parent)
 	}
-	if len(recipe) == 0 {
-		recipe = DefaultRecipe()
+	if scopeKey == "" && scope == ScopeRegion {
+		// The region component may be omitted from regional names, so the
+		// scope key falls back to the resolved region code.
+		scopeKey = regionCode
+		if v := components["region"]; v != "" {
+			scopeKey = v
+		}
+	}
+	regionCode = components["region"]
+	zoneCode = components["zone"]
+
+	if validate {
+		if err := validateComponentRules(components, effective.ComponentRules); err != nil {
+			return BuildResult{}, err
+		}
 	}
 
+	recipe, recipeSource := resolveRecipe(in.Recipe, resourceLookupKeys, effective)
+
 	parts := make([]string, 0, len(recipe))
+	// The hash goes in last, once the rest of the name is known; an override
+	// replaces it like any other component.
+	hashIndex := -1
 	for _, item := range recipe {
 		item = strings.TrimSpace(item)
 		if item == "" {
 			continue
 		}
 		canonical := canonicalComponentKey(item)
+		if droppedComponents[canonical] {
+			continue
+		}
+		if canonical == HashComponent {
+			if _, overridden := components[HashComponent]; !overridden {
+				if hashIndex < 0 {
+					hashIndex = len(parts)
+				}
+				continue
+			}
+		}
 		val := ""
 		if v, ok := components[canonical]; ok {
 			val = v
@@ -213,22 +371,86 @@ func BuildName(cfg Config, in BuildInput) (BuildResult, error) {
 			chosenStyle = StyleDashed
 		}
 	}
+	styleFallback := ""
+	if len(allowedStyles) > 0 && !containsNormalizedStyle(stylePriority, chosenStyle) {
+		styleFallback = fmt.Sprintf("uses style %q because the style priority allows none of %s", chosenStyle, strings.Join(allowedStyles, ", "))
+	}
 
-	name, err := formatName(chosenStyle, parts)
+	split := c.split
+	name, err := formatName(chosenStyle, parts, split)
 	if err != nil {
 		return BuildResult{}, err
 	}
-	if err := validateResourceConstraints(resourceLookupKeys, name, effective.ResourceConstraints); err != nil {
+	if hashIndex >= 0 {
+		var pattern *regexp.Regexp
+		if _, constraint, ok := lookupResourceConstraint(resourceLookupKeys, effective.ResourceConstraints); ok {
+			pattern = constraint.Pattern
+		}
+		hash, err := c.hash.compute(components, name, pattern)
+		if err != nil {
+			return BuildResult{}, err
+		}
+		components[HashComponent] = hash
+		parts = append(parts[:hashIndex], append([]string{hash}, parts[hashIndex:]...)...)
+		if name, err = formatName(chosenStyle, parts, split); err != nil {
+			return BuildResult{}, err
+		}
+	}
+	affix, _ := lookupResourceAffix(resourceLookupKeys, effective.ResourceAffixes)
+	if name, err = affix.apply(resourceKey, name, in.Fifo); err != nil {
+		return BuildResult{}, err
+	}
+	var violations []ConstraintError
+	if validate {
+		violations, err = c.enforceConstraints(resourceLookupKeys, name, parts, styleFallback)
+		if err != nil {
+			return BuildResult{}, err
+		}
+	}
+
+	displayRecipe := effective.DisplayRecipe
+	if len(in.DisplayRecipe) > 0 {
+		displayRecipe = in.DisplayRecipe
+	}
+	if len(displayRecipe) == 0 {
+		displayRecipe = DefaultDisplayRecipe()
+	}
+	displayStyle := effective.DisplayStyle
+	if strings.TrimSpace(in.DisplayStyle) != "" {
+		displayStyle = in.DisplayStyle
+	}
+	displayName, err := buildDisplayName(displayContext{
+		components:  components,
+		region:      strings.TrimSpace(effective.Region),
+		regions:     c.regions,
+		resourceKey: resourceKey,
+		acronym:     resourceAcronym,
+		expansions:  c.displayExpansions,
+		split:       split,
+	}, displayRecipe, displayStyle)
+	if err != nil {
 		return BuildResult{}, err
 	}
+	if validate {
+		violations = append(violations, c.enforceDisplayConstraints(resourceLookupKeys, displayName)...)
+	}
 
 	return BuildResult{
 		Name:            name,
+		DisplayName:     displayName,
 		Style:           chosenStyle,
 		Components:      components,
 		Parts:           parts,
 		RegionCode:      regionCode,
+		ZoneCode:        zoneCode,
 		ResourceAcronym: components["resource"],
+		RecipeSource:    recipeSource,
+		Warnings:        warnings,
+		Violations:      violations,
+		UnknownRegions:  unknownRegions,
+		Scope:           scope,
+		ScopeKey:        scopeKey,
+		ParentMode:      parentMode,
 	}, nil
 }
 
@@ -242,12 +464,20 @@ func canonicalComponentKey(key string) string {
 		return "env"
 	case "region", "region_code", "region_short_code":
 		return "region"
+	case "zone", "zone_code", "availability_zone", "az":
+		return "zone"
 	case "resource", "resource_type":
 		return "resource"
 	case "what":
 		return "resource"
 	case "qualifier", "qual":
 		return "qualifier"
+	case "account", "account_id", "aws_account", "aws_account_id":
+		return "account"
+	case "subscription", "subscription_id", "azure_subscription", "azure_subscription_id":
+		return "subscription"
+	case "gcp_project", "gcp_project_id", "gcp_project_number", "project_id", "project_number":
+		return "gcp_project"
 	default:
 		return strings.ToLower(strings.TrimSpace(key))
 	}
@@ -257,6 +487,47 @@ func normalizeStyle(style string) string {
 	return strings.ToLower(strings.TrimSpace(style))
 }
 
+// FillCloudDefaults fills each map cfg leaves empty from the defaults of its
+// cloud. Maps that are set are kept as they are.
+func FillCloudDefaults(cfg Config) (Config, error) {
+	effective := cfg
+	defaults, err := DefaultCloudDefaults(effective.Cloud)
+	if err != nil {
+		return Config{}, err
+	}
+	if len(effective.RegionMap) == 0 {
+		effective.RegionMap = defaults.RegionMap
+	}
+	if len(effective.MultiRegionLocations) == 0 {
+		effective.MultiRegionLocations = defaults.MultiRegionLocations
+	}
+	if len(effective.ResourceAcronyms) == 0 {
+		effective.ResourceAcronyms = defaults.ResourceAcronyms
+	}
+	if len(effective.ResourceStyleOverrides) == 0 {
+		effective.ResourceStyleOverrides = defaults.ResourceStyleOverrides
+	}
+	if len(effective.ResourceConstraints) == 0 {
+		effective.ResourceConstraints = defaults.ResourceConstraints
+	}
+	if len(effective.RegionalResources) == 0 {
+		effective.RegionalResources = defaults.RegionalResources
+	}
+	if len(effective.ZonalResources) == 0 {
+		effective.ZonalResources = defaults.ZonalResources
+	}
+	if len(effective.DisplayConstraints) == 0 {
+		effective.DisplayConstraints = defaults.DisplayConstraints
+	}
+	if len(effective.ResourceScopes) == 0 {
+		effective.ResourceScopes = defaults.ResourceScopes
+	}
+	if len(effective.ResourceAffixes) == 0 {
+		effective.ResourceAffixes = defaults.ResourceAffixes
+	}
+	return effective, nil
+}
+
 func resourceLookupCandidates(cloud, resourceKey string) []string {
 	resourceKey = strings.ToLower(strings.TrimSpace(resourceKey))
 	if resourceKey == "" {
@@ -289,6 +560,30 @@ func lookupResourceAcronym(resourceKeys []string, acronyms map[string]string) (s
 	return "", false
 }
 
+// resolveRecipe picks the recipe for a name: the request recipe, then the
+// resource recipe, then the configured recipe, then DefaultRecipe.
+func resolveRecipe(requested []string, resourceKeys []string, cfg Config) ([]string, string) {
+	if len(requested) > 0 {
+		return requested, RecipeSourceMark
+	}
+	if v, ok := lookupResourceRecipe(resourceKeys, cfg.ResourceRecipes); ok {
+		return v, RecipeSourceResource
+	}
+	if len(cfg.Recipe) > 0 {
+		return cfg.Recipe, RecipeSourceProvider
+	}
+	return DefaultRecipe(), RecipeSourceDefault
+}
+
+func lookupResourceRecipe(resourceKeys []string, recipes map[string][]string) ([]string, bool) {
+	for _, resourceKey := range resourceKeys {
+		if v, ok := recipes[resourceKey]; ok && len(v) > 0 {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
 func lookupResourceStyles(resourceKeys []string, styles map[string][]string) ([]string, bool) {
 	for _, resourceKey := range resourceKeys {
 		if v, ok := styles[resourceKey]; ok {
@@ -319,7 +614,37 @@ func isRegionalResource(resourceKeys []string, regionalResources map[string]bool
 	return false
 }
 
-func validateResourceConstraints(resourceKeys []string, name string, constraints map[string]ResourceConstraint) error {
+// Constraint categories reported in ConstraintError.Category.
+const (
+	ConstraintLength    = "length"
+	ConstraintPattern   = "pattern"
+	ConstraintForbidden = "forbidden"
+)
+
+// ConstraintError reports a name that violates its ResourceConstraint.
+type ConstraintError struct {
+	Resource string
+	Name     string
+	Category string
+	Reason   string
+	// Display marks a violation of the display name rather than the name.
+	Display bool
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Display {
+		return fmt.Sprintf("resource %q display name %q %s", e.Resource, e.Name, e.Reason)
+	}
+	return fmt.Sprintf("resource %q name %q %s", e.Resource, e.Name, e.Reason)
+}
+
+func constraintError(resource, name, category, reason string) error {
+	return &ConstraintError{Resource: resource, Name: name, Category: category, Reason: reason}
+}
+
+// validateResourceConstraints returns the first violation of the constraint
+// of the resource, ignoring the categories in skip.
+func validateResourceConstraints(resourceKeys []string, name string, constraints map[string]ResourceConstraint, skip map[string]bool) error {
 	if len(resourceKeys) == 0 || len(name) == 0 {
 		return nil
 	}
@@ -327,18 +652,21 @@ func validateResourceConstraints(resourceKeys []string, name string, constraints
 	if !ok {
 		return nil
 	}
-	if c.MinLen > 0 && len(name) < c.MinLen {
-		return fmt.Errorf("resource %q name %q is shorter than %d characters", resourceKey, name, c.MinLen)
+	if !skip[ConstraintLength] && c.MinLen > 0 && len(name) < c.MinLen {
+		return constraintError(resourceKey, name, ConstraintLength, fmt.Sprintf("is shorter than %d characters", c.MinLen))
 	}
-	if c.MaxLen > 0 && len(name) > c.MaxLen {
-		return fmt.Errorf("resource %q name %q exceeds %d characters", resourceKey, name, c.MaxLen)
+	if !skip[ConstraintLength] && c.MaxLen > 0 && len(name) > c.MaxLen {
+		return constraintError(resourceKey, name, ConstraintLength, fmt.Sprintf("exceeds %d characters", c.MaxLen))
 	}
-	if c.Pattern != nil && !c.Pattern.MatchString(name) {
+	if !skip[ConstraintPattern] && c.Pattern != nil && !c.Pattern.MatchString(name) {
 		desc := c.PatternDescription
 		if desc == "" {
 			desc = c.Pattern.String()
 		}
-		return fmt.Errorf("resource %q name %q must match: %s", resourceKey, name, desc)
+		return constraintError(resourceKey, name, ConstraintPattern, "must match: "+desc)
+	}
+	if skip[ConstraintForbidden] {
+		return nil
 	}
 	comparisonName := name
 	if c.CaseInsensitive {
@@ -354,7 +682,7 @@ func validateResourceConstraints(resourceKeys []string, name string, constraints
 				candidate = strings.ToLower(prefix)
 			}
 			if strings.HasPrefix(comparisonName, candidate) {
-				return fmt.Errorf("resource %q name %q must not start with prefix %q", resourceKey, name, prefix)
+				return constraintError(resourceKey, name, ConstraintForbidden, fmt.Sprintf("must not start with prefix %q", prefix))
 			}
 		}
 	}
@@ -368,7 +696,7 @@ func validateResourceConstraints(resourceKeys []string, name string, constraints
 				candidate = strings.ToLower(suffix)
 			}
 			if strings.HasSuffix(comparisonName, candidate) {
-				return fmt.Errorf("resource %q name %q must not end with suffix %q", resourceKey, name, suffix)
+				return constraintError(resourceKey, name, ConstraintForbidden, fmt.Sprintf("must not end with suffix %q", suffix))
 			}
 		}
 	}
@@ -382,7 +710,7 @@ func validateResourceConstraints(resourceKeys []string, name string, constraints
 				candidate = strings.ToLower(sub)
 			}
 			if strings.Contains(comparisonName, candidate) {
-				return fmt.Errorf("resource %q name %q must not contain %q", resourceKey, name, sub)
+				return constraintError(resourceKey, name, ConstraintForbidden, fmt.Sprintf("must not contain %q", sub))
 			}
 		}
 	}
@@ -392,12 +720,36 @@ func validateResourceConstraints(resourceKeys []string, name string, constraints
 				continue
 			}
 			if pattern.MatchString(name) {
-				return fmt.Errorf("resource %q name %q must not match forbidden pattern %q", resourceKey, name, pattern.String())
+				return constraintError(resourceKey, name, ConstraintForbidden, fmt.Sprintf("must not match forbidden pattern %q", pattern.String()))
 			}
 		}
 	}
 	if c.DisallowIPAddress && isIPv4Address(name) {
-		return fmt.Errorf("resource %q name %q must not be formatted as an IP address", resourceKey, name)
+		return constraintError(resourceKey, name, ConstraintForbidden, "must not be formatted as an IP address")
+	}
+	return nil
+}
+
+// defaultDisplayConstraints holds DefaultDisplayConstraint under the key
+// validateDisplayConstraints falls back to.
+var defaultDisplayConstraints = map[string]ResourceConstraint{"display": DefaultDisplayConstraint()}
+
+func validateDisplayConstraints(resourceKeys []string, displayName string, constraints map[string]ResourceConstraint, skip map[string]bool) *ConstraintError {
+	if len(displayName) == 0 {
+		return nil
+	}
+	keys := resourceKeys
+	if _, _, ok := lookupResourceConstraint(resourceKeys, constraints); !ok {
+		keys = []string{"display"}
+		constraints = defaultDisplayConstraints
+	}
+	var violation *ConstraintError
+	if err := validateResourceConstraints(keys, displayName, constraints, skip); errors.As(err, &violation) {
+		violation.Display = true
+		if len(resourceKeys) > 0 {
+			violation.Resource = resourceKeys[0]
+		}
+		return violation
 	}
 	return nil
 }
@@ -419,6 +771,11 @@ func normalizeStyles(styles []string) []string {
 	return out
 }
 
+// IsValidStyle reports whether style names a supported naming style.
+func IsValidStyle(style string) bool {
+	return isValidStyle(normalizeStyle(style))
+}
+
 func isValidStyle(style string) bool {
 	switch style {
 	case StyleDashed, StyleUnderscore, StyleStraight, StylePascal, StylePascalDashed, StyleCamel:
@@ -428,26 +785,26 @@ func isValidStyle(style string) bool {
 	}
 }
 
-func formatName(style string, parts []string) (string, error) {
+func formatName(style string, parts []string, split wordSplitter) (string, error) {
 	switch style {
 	case StyleDashed:
-		return strings.Join(normalizeParts(parts, "-", false), "-"), nil
+		return strings.Join(normalizeParts(parts, "-", false, split), "-"), nil
 	case StyleUnderscore:
-		return strings.Join(normalizeParts(parts, "_", false), "_"), nil
+		return strings.Join(normalizeParts(parts, "_", false, split), "_"), nil
 	case StyleStraight:
-		return strings.Join(normalizeParts(parts, "", false), ""), nil
+		return strings.Join(normalizeParts(parts, "", false, split), ""), nil
 	case StylePascal:
-		return strings.Join(normalizeParts(parts, "", true), ""), nil
+		return strings.Join(normalizeParts(parts, "", true, split), ""), nil
 	case StylePascalDashed:
-		return pascalDashedize(parts), nil
+		return pascalDashedize(parts, split), nil
 	case StyleCamel:
-		return camelize(parts), nil
+		return camelize(parts, split), nil
 	default:
 		return "", errors.New("unsupported style")
 	}
 }
 
-func normalizeParts(parts []string, sep string, pascal bool) []string {
+func normalizeParts(parts []string, sep string, pascal bool, split wordSplitter) []string {
 	out := make([]string, 0, len(parts))
 	for _, p := range parts {
 		p = strings.TrimSpace(p)
@@ -455,10 +812,10 @@ func normalizeParts(parts []string, sep string, pascal bool) []string {
 			continue
 		}
 		if pascal {
-			out = append(out, pascalize(p))
+			out = append(out, pascalize(p, split))
 			continue
 		}
-		words := splitWords(p)
+		words := split.words(p)
 		if len(words) == 0 {
 			continue
 		}
@@ -471,14 +828,18 @@ func normalizeParts(parts []string, sep string, pascal bool) []string {
 	return out
 }
 
-func camelize(parts []string) string {
+func camelize(parts []string, split wordSplitter) string {
 	if len(parts) == 0 {
 		return ""
 	}
-	firstWords := splitWords(parts[0])
+	firstWords := split.words(parts[0])
 	first := ""
 	if len(firstWords) > 0 {
-		first = strings.ToLower(strings.Join(firstWords, ""))
+		if split.caseAware() {
+			first = strings.ToLower(firstWords[0]) + pascalize(strings.Join(firstWords[1:], " "), split)
+		} else {
+			first = strings.ToLower(strings.Join(firstWords, ""))
+		}
 	}
 
 	rest := make([]string, 0, len(parts))
@@ -487,20 +848,20 @@ func camelize(parts []string) string {
 		if p == "" {
 			continue
 		}
-		rest = append(rest, pascalize(p))
+		rest = append(rest, pascalize(p, split))
 	}
 
 	return first + strings.Join(rest, "")
 }
 
-func pascalDashedize(parts []string) string {
+func pascalDashedize(parts []string, split wordSplitter) string {
 	words := make([]string, 0, len(parts))
 	for _, p := range parts {
 		p = strings.TrimSpace(p)
 		if p == "" {
 			continue
 		}
-		for _, w := range splitWords(p) {
+		for _, w := range split.words(p) {
 			if w == "" {
 				continue
 			}
@@ -510,8 +871,8 @@ func pascalDashedize(parts []string) string {
 	return strings.Join(words, "-")
 }
 
-func pascalize(value string) string {
-	words := splitWords(value)
+func pascalize(value string, split wordSplitter) string {
+	words := split.words(value)
 	if len(words) == 0 {
 		return ""
 	}
@@ -554,34 +915,76 @@ func firstValidStyle(styles []string) string {
 	return ""
 }
 
-func lookupRegionCode(regionMap map[string]string, region string) string {
-	region = strings.TrimSpace(region)
-	if region == "" || len(regionMap) == 0 {
-		return ""
-	}
+// RegionCode resolves the short code used for region. An explicit shortCode
+// wins; otherwise the region is looked up in regionMap and used as-is when
+// the map has no entry for it.
+func RegionCode(regionMap map[string]string, region, shortCode string) string {
+	return newRegionIndex(regionMap, nil).code(region, shortCode)
+}
 
-	if code := strings.TrimSpace(regionMap[region]); code != "" {
-		return code
-	}
-	if lower := strings.ToLower(region); lower != region {
-		if code := strings.TrimSpace(regionMap[lower]); code != "" {
-			return code
-		}
-	}
+// EnvCode returns the short code mapped to env in envMap, or the trimmed env
+// when no mapping exists. Keys are matched the same way as region names, so
+// "Production", "production" and "PRODUCTION" all resolve to one entry.
+func EnvCode(envMap map[string]string, env string) string {
+	return mappedCode(envMap, env)
+}
 
-	target := normalizeRegionKey(region)
-	if target == "" {
-		return ""
+// accountComponents are the components whose values are shortened through
+// Config.AccountMap.
+var accountComponents = []string{"account", "subscription", "gcp_project"}
+
+// AccountCode returns the alias mapped to id in accountMap, or the trimmed id
+// when no mapping exists. Keys are matched like EnvCode keys, so
+// "1234-5678-9012" and "123456789012" resolve to one entry.
+func AccountCode(accountMap map[string]string, id string) string {
+	return mappedCode(accountMap, id)
+}
+
+func mappedCode(codes map[string]string, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || len(codes) == 0 {
+		return value
+	}
+	if code := strings.TrimSpace(codes[value]); code != "" {
+		return code
 	}
-	for key, code := range regionMap {
+	target := normalizeRegionKey(value)
+	for key, code := range codes {
 		if normalizeRegionKey(key) == target {
 			if trimmed := strings.TrimSpace(code); trimmed != "" {
 				return trimmed
 			}
 		}
 	}
+	return value
+}
 
-	return ""
+// IsAllowedEnv reports whether env, or the code it maps to, is listed in allowed.
+func IsAllowedEnv(allowed []string, envMap map[string]string, env string) bool {
+	candidates := []string{normalizeRegionKey(env), normalizeRegionKey(EnvCode(envMap, env))}
+	for _, value := range allowed {
+		normalized := normalizeRegionKey(value)
+		if normalized == "" {
+			continue
+		}
+		if containsString(candidates, normalized) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAllowedEnv returns a ComponentError when cfg lists allowed envs and
+// env is not one of them.
+func checkAllowedEnv(cfg Config, env string) error {
+	if len(cfg.AllowedEnvs) == 0 || IsAllowedEnv(cfg.AllowedEnvs, cfg.EnvMap, env) {
+		return nil
+	}
+	return &ComponentError{
+		Component: "env",
+		Value:     env,
+		Reason:    fmt.Sprintf("is not listed in allowed envs (%s)", strings.Join(cfg.AllowedEnvs, ", ")),
+	}
 }
 
 func normalizeRegionKey(value string) string {
@@ -1,6 +1,15 @@
 package naming
 
-import "testing"
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+)
 
 func TestDefaultCloudDefaultsAzure(t *testing.T) {
 	defaults, err := DefaultCloudDefaults(CloudAzure)
@@ -538,7 +547,7 @@ func TestBuildNameGCPComputeRouterRejectsUnderscoreStyle(t *testing.T) {
 }
 
 func TestDefaultGCPPrimaryResourceAcronymsAreUnique(t *testing.T) {
-	acronyms := DefaultGCPResourceAcronyms()
+	acronyms := defaultResourceAcronyms(t, CloudGCP)
 	owners := map[string]string{}
 	aliases := map[string]bool{
 		"gcs_bucket":        true,
@@ -563,7 +572,7 @@ func TestDefaultGCPPrimaryResourceAcronymsAreUnique(t *testing.T) {
 }
 
 func TestDefaultGCPPrimaryResourceAcronymsStayCompact(t *testing.T) {
-	acronyms := DefaultGCPResourceAcronyms()
+	acronyms := defaultResourceAcronyms(t, CloudGCP)
 	aliases := map[string]bool{
 		"gcs_bucket":        true,
 		"gcs":               true,
@@ -584,3 +593,1518 @@ func TestDefaultGCPPrimaryResourceAcronymsStayCompact(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildNameDisplayNameExpandsRegionAndResource(t *testing.T) {
+	result, err := BuildName(Config{
+		Cloud:     CloudAzure,
+		OrgPrefix: "acme",
+		Project:   "payments",
+		Env:       "prod",
+		Region:    "westeurope",
+	}, BuildInput{
+		Resource:      "azurerm_virtual_network",
+		Qualifier:     "payments-worker",
+		DisplayRecipe: []string{"org", "env", "region", "-", "resource", "qualifier"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+
+	if result.DisplayName != "Acme Prod West Europe - Virtual Network Payments Worker" {
+		t.Fatalf("unexpected display name %q", result.DisplayName)
+	}
+}
+
+func TestBuildNameDisplayNameDefaultRecipeAndExpansions(t *testing.T) {
+	result, err := BuildName(Config{
+		Cloud:             CloudGCP,
+		OrgPrefix:         "org",
+		Project:           "proj",
+		Env:               "prod",
+		Region:            "europe-west1",
+		DisplayExpansions: map[string]string{"PROJ": "Project X"},
+	}, BuildInput{
+		Resource:  "google_service_account",
+		Qualifier: "billing_worker",
+		Recipe:    []string{"org", "proj", "env", "qualifier"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+
+	if result.DisplayName != "Org Project X Prod - Billing Worker" {
+		t.Fatalf("unexpected display name %q", result.DisplayName)
+	}
+	if result.Name != "org-proj-prod-billing-worker" {
+		t.Fatalf("expected technical name to be unaffected, got %q", result.Name)
+	}
+}
+
+func TestBuildNameDisplayNameSentenceStyleKeepsAcronyms(t *testing.T) {
+	result, err := BuildName(Config{
+		OrgPrefix:    "acme",
+		Region:       "us-east-1",
+		DisplayStyle: DisplayStyleSentence,
+	}, BuildInput{
+		Resource:      "iam_role",
+		Qualifier:     "deploy",
+		DisplayRecipe: []string{"region", "resource", "qualifier"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+
+	if result.DisplayName != "US east 1 IAM role deploy" {
+		t.Fatalf("unexpected display name %q", result.DisplayName)
+	}
+}
+
+func TestBuildNameDisplayNameRejectsInvalidStyle(t *testing.T) {
+	_, err := BuildName(Config{OrgPrefix: "acme"}, BuildInput{
+		Resource:     "lambda",
+		DisplayStyle: "shouting",
+	})
+	if err == nil {
+		t.Fatal("expected unsupported display style error, got nil")
+	}
+}
+
+func TestBuildNameDisplayNameEnforcesDisplayConstraints(t *testing.T) {
+	result, err := BuildName(Config{
+		Cloud:     CloudGCP,
+		OrgPrefix: "acme",
+		Project:   "shop",
+		Env:       "prod",
+		Region:    "europe-west1",
+	}, BuildInput{
+		Resource:      "project",
+		Qualifier:     "pay",
+		DisplayRecipe: []string{"org", "proj", "env", "region", "resource", "qualifier"},
+	})
+	if err != nil {
+		t.Fatalf("expected the name to be built despite the display name, got %v", err)
+	}
+	if result.Name == "" || len(result.Violations) != 1 {
+		t.Fatalf("expected a name and one display violation, got %q %+v", result.Name, result.Violations)
+	}
+	violation := result.Violations[0]
+	if !violation.Display || violation.Category != ConstraintLength || violation.Resource != "project" {
+		t.Fatalf("unexpected display violation: %+v", violation)
+	}
+	if !strings.Contains(violation.Error(), "display name") {
+		t.Fatalf("expected the message to name the display name, got %q", violation.Error())
+	}
+
+	if _, err := Compile(Config{Cloud: CloudGCP, DisplayStyle: "titel"}); err == nil {
+		t.Fatal("expected an unsupported display_style to fail compilation")
+	}
+}
+
+func TestZoneShortCodePerCloud(t *testing.T) {
+	cases := []struct {
+		cloud     string
+		regionMap map[string]string
+		zone      string
+		want      string
+	}{
+		{CloudAWS, DefaultRegionMap(), "us-east-1a", "use1a"},
+		{CloudAWS, DefaultRegionMap(), "us-west-2-lax-1a", "usw2lax1a"},
+		{CloudGCP, DefaultGCPRegionMap(), "europe-west1-b", "euw1b"},
+		{CloudAzure, DefaultAzureRegionMap(), "1", "z1"},
+		{CloudAWS, DefaultRegionMap(), "use1-az4", "use1az4"},
+	}
+
+	for _, tc := range cases {
+		if got := zoneShortCode(tc.cloud, tc.zone, newRegionIndex(tc.regionMap, nil)); got != tc.want {
+			t.Fatalf("%s zone %q: expected %q, got %q", tc.cloud, tc.zone, tc.want, got)
+		}
+	}
+}
+
+func TestBuildNameZoneOnlyAppliesToZonalResources(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudGCP,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		Zone:      "europe-west1-b",
+	}
+	recipe := []string{"org", "env", "zone", "resource", "qualifier"}
+
+	result, err := BuildName(cfg, BuildInput{
+		Resource:  "google_compute_instance",
+		Qualifier: "api",
+		Recipe:    recipe,
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-dev-euw1b-gce-api" {
+		t.Fatalf("expected zonal name %q, got %q", "acme-dev-euw1b-gce-api", result.Name)
+	}
+	if result.ZoneCode != "euw1b" {
+		t.Fatalf("expected zone code %q, got %q", "euw1b", result.ZoneCode)
+	}
+
+	result, err = BuildName(cfg, BuildInput{
+		Resource:  "google_storage_bucket",
+		Qualifier: "api",
+		Recipe:    recipe,
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-dev-gcs-api" {
+		t.Fatalf("expected provider zone to be dropped for non-zonal resource, got %q", result.Name)
+	}
+
+	result, err = BuildName(cfg, BuildInput{
+		Resource:  "google_storage_bucket",
+		Qualifier: "api",
+		Zone:      "europe-west1-c",
+		Recipe:    recipe,
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-dev-euw1c-gcs-api" {
+		t.Fatalf("expected explicit zone to be honored, got %q", result.Name)
+	}
+}
+
+func TestBuildNameMapsEnvToShortCode(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "Production",
+		EnvMap: map[string]string{
+			"production": "prd",
+			"staging":    "stg",
+		},
+	}
+
+	result, err := BuildName(cfg, BuildInput{
+		Resource:  "sqs",
+		Qualifier: "jobs",
+		Recipe:    []string{"org", "env", "resource", "qualifier"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-prd-sqs-jobs" {
+		t.Fatalf("expected mapped env in name, got %q", result.Name)
+	}
+	if result.Components["env"] != "prd" {
+		t.Fatalf("expected env component %q, got %q", "prd", result.Components["env"])
+	}
+	if result.Components["env_raw"] != "Production" {
+		t.Fatalf("expected env_raw component %q, got %q", "Production", result.Components["env_raw"])
+	}
+}
+
+func TestIsAllowedEnvMatchesRawOrCode(t *testing.T) {
+	envMap := map[string]string{"production": "prd"}
+
+	if !IsAllowedEnv([]string{"prd"}, envMap, "PRODUCTION") {
+		t.Fatalf("expected mapped code to satisfy allowlist")
+	}
+	if !IsAllowedEnv([]string{"production"}, envMap, "production") {
+		t.Fatalf("expected raw env to satisfy allowlist")
+	}
+	if IsAllowedEnv([]string{"prd", "dev"}, envMap, "prod") {
+		t.Fatalf("expected unmapped env outside allowlist to be rejected")
+	}
+}
+
+func TestBuildNameRejectsEnvOverridesOutsideAllowedEnvs(t *testing.T) {
+	cfg := Config{
+		Cloud:       CloudAWS,
+		OrgPrefix:   "acme",
+		Env:         "dev",
+		EnvMap:      map[string]string{"production": "prd"},
+		AllowedEnvs: []string{"dev", "prd"},
+	}
+
+	for _, key := range []string{"env", "environment"} {
+		_, err := BuildName(cfg, BuildInput{Resource: "sqs", Overrides: map[string]string{key: "sandbox"}})
+		var componentErr *ComponentError
+		if !errors.As(err, &componentErr) || componentErr.Component != "env" {
+			t.Fatalf("expected env component error for %q override, got %v", key, err)
+		}
+	}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs", Overrides: map[string]string{"environment": "Production"}, Recipe: []string{"org", "env", "resource"}})
+	if err != nil {
+		t.Fatalf("expected env listed through its code to be allowed, got %v", err)
+	}
+	if result.Name != "acme-prd-sqs" || result.Components["env"] != "prd" {
+		t.Fatalf("expected override to be mapped through env_map, got %q (env %q)", result.Name, result.Components["env"])
+	}
+	if result.Components["env_raw"] != "Production" {
+		t.Fatalf("expected env_raw to hold the raw override, got %q", result.Components["env_raw"])
+	}
+
+	cfg.Env = "sandbox"
+	if _, err := Compile(cfg); err == nil {
+		t.Fatal("expected Compile to reject an env outside allowed envs")
+	}
+}
+
+func TestBuildNameEnforcesComponentRules(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme corp",
+		Project:   "payments",
+		Env:       "dev",
+		ComponentRules: map[string]ComponentRule{
+			"org_prefix": {Pattern: regexp.MustCompile(`^[a-z0-9]+$`)},
+		},
+	}
+
+	_, err := BuildName(cfg, BuildInput{Resource: "sqs"})
+	var componentErr *ComponentError
+	if !errors.As(err, &componentErr) {
+		t.Fatalf("expected component error, got %v", err)
+	}
+	if componentErr.Component != "org" {
+		t.Fatalf("expected error to name component %q, got %q", "org", componentErr.Component)
+	}
+
+	cfg.OrgPrefix = "acme"
+	cfg.ComponentRules = map[string]ComponentRule{
+		"project": {MaxLen: 4},
+	}
+	if _, err := BuildName(cfg, BuildInput{Resource: "sqs"}); err == nil || !strings.Contains(err.Error(), `component "proj" value "payments" exceeds 4 characters`) {
+		t.Fatalf("expected project length error, got %v", err)
+	}
+}
+
+func TestBuildNameComponentRulesCoverCustomKeys(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		ComponentRules: map[string]ComponentRule{
+			"team": {Required: true, AllowedValues: []string{"core", "data"}},
+		},
+	}
+	recipe := []string{"org", "team", "env", "resource"}
+
+	if _, err := BuildName(cfg, BuildInput{Resource: "sqs", Recipe: recipe}); err == nil || !strings.Contains(err.Error(), `component "team" value "" is required`) {
+		t.Fatalf("expected required error, got %v", err)
+	}
+
+	if _, err := BuildName(cfg, BuildInput{Resource: "sqs", Recipe: recipe, Overrides: map[string]string{"team": "ops"}}); err == nil || !strings.Contains(err.Error(), "must be one of: core, data") {
+		t.Fatalf("expected allowed values error, got %v", err)
+	}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs", Recipe: recipe, Overrides: map[string]string{"team": "Data"}})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-data-dev-sqs" {
+		t.Fatalf("expected name %q, got %q", "acme-data-dev-sqs", result.Name)
+	}
+}
+
+func TestBuildNameResourceRecipePrecedence(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Project:   "iac",
+		Env:       "dev",
+		Region:    "us-east-1",
+		Recipe:    []string{"org", "proj", "env", "resource", "qualifier"},
+		ResourceRecipes: map[string][]string{
+			"iam_role": {"org", "env", "resource", "qualifier"},
+		},
+	}
+
+	cases := []struct {
+		name         string
+		in           BuildInput
+		expectedName string
+		expectedFrom string
+	}{
+		{
+			name:         "resource recipe",
+			in:           BuildInput{Resource: "iam_role", Qualifier: "app"},
+			expectedName: "acme-dev-role-app",
+			expectedFrom: RecipeSourceResource,
+		},
+		{
+			name:         "provider recipe",
+			in:           BuildInput{Resource: "sqs", Qualifier: "app"},
+			expectedName: "acme-iac-dev-sqs-app",
+			expectedFrom: RecipeSourceProvider,
+		},
+		{
+			name:         "mark recipe",
+			in:           BuildInput{Resource: "iam_role", Qualifier: "app", Recipe: []string{"org", "resource", "qualifier"}},
+			expectedName: "acme-role-app",
+			expectedFrom: RecipeSourceMark,
+		},
+	}
+
+	for _, tc := range cases {
+		result, err := BuildName(cfg, tc.in)
+		if err != nil {
+			t.Fatalf("%s: unexpected build error: %v", tc.name, err)
+		}
+		if result.Name != tc.expectedName {
+			t.Fatalf("%s: expected name %q, got %q", tc.name, tc.expectedName, result.Name)
+		}
+		if result.RecipeSource != tc.expectedFrom {
+			t.Fatalf("%s: expected recipe source %q, got %q", tc.name, tc.expectedFrom, result.RecipeSource)
+		}
+	}
+
+	cfg.Recipe = nil
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.RecipeSource != RecipeSourceDefault {
+		t.Fatalf("expected recipe source %q, got %q", RecipeSourceDefault, result.RecipeSource)
+	}
+}
+
+func TestSplitCaseWords(t *testing.T) {
+	cases := []struct {
+		value    string
+		digits   bool
+		expected []string
+	}{
+		{value: "paymentWorker", expected: []string{"payment", "Worker"}},
+		{value: "APIGateway", expected: []string{"API", "Gateway"}},
+		{value: "HTTPServer", expected: []string{"HTTP", "Server"}},
+		{value: "s3b", expected: []string{"s3b"}},
+		{value: "v2Api", expected: []string{"v2", "Api"}},
+		{value: "node2Pool", digits: true, expected: []string{"node", "2", "Pool"}},
+		{value: "my_jobQueue", expected: []string{"my", "job", "Queue"}},
+	}
+
+	for _, tc := range cases {
+		got := splitCaseWords(tc.value, tc.digits)
+		if strings.Join(got, ",") != strings.Join(tc.expected, ",") {
+			t.Fatalf("splitCaseWords(%q, %t): expected %v, got %v", tc.value, tc.digits, tc.expected, got)
+		}
+	}
+}
+
+func TestBuildNameWordSplitModes(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		Recipe:    []string{"org", "env", "resource", "qualifier"},
+	}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "paymentWorker"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-dev-sqs-paymentworker" {
+		t.Fatalf("expected legacy alnum splitting by default, got %q", result.Name)
+	}
+
+	cfg.WordSplit = WordSplitCase
+	expected := map[string]string{
+		StyleDashed:       "acme-dev-sqs-payment-worker",
+		StyleUnderscore:   "acme_dev_sqs_payment_worker",
+		StylePascal:       "AcmeDevSqsPaymentWorker",
+		StylePascalDashed: "Acme-Dev-Sqs-Payment-Worker",
+	}
+	for style, name := range expected {
+		result, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "paymentWorker", StylePriority: []string{style}})
+		if err != nil {
+			t.Fatalf("%s: unexpected build error: %v", style, err)
+		}
+		if result.Name != name {
+			t.Fatalf("%s: expected %q, got %q", style, name, result.Name)
+		}
+	}
+
+	result, err = BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "worker", Overrides: map[string]string{"org": "bigCorp"}, StylePriority: []string{StyleCamel}})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "bigCorpDevSqsWorker" {
+		t.Fatalf("expected camel name to keep inner boundaries, got %q", result.Name)
+	}
+
+	cfg.WordSplit = "snake"
+	if _, err := BuildName(cfg, BuildInput{Resource: "sqs"}); err == nil || !strings.Contains(err.Error(), "unsupported word_split") {
+		t.Fatalf("expected invalid word_split error, got %v", err)
+	}
+}
+
+func TestBuildNameTransliteratesComponents(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Project:   "Zürich",
+		Env:       "dev",
+		Recipe:    []string{"org", "proj", "env", "resource", "qualifier"},
+	}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "łódź"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-zuerich-dev-sqs-lodz" {
+		t.Fatalf("expected transliterated name, got %q", result.Name)
+	}
+	if result.Components["proj"] != "Zuerich" {
+		t.Fatalf("expected transliterated proj component, got %q", result.Components["proj"])
+	}
+	if len(result.Warnings) != 2 {
+		t.Fatalf("expected two transliteration warnings, got %v", result.Warnings)
+	}
+
+	result, err = BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "東京app"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Components["qualifier"] != "app" {
+		t.Fatalf("expected untransliterable characters to be stripped, got %q", result.Components["qualifier"])
+	}
+	if !strings.Contains(strings.Join(result.Warnings, "\n"), "dropped characters") {
+		t.Fatalf("expected dropped characters warning, got %v", result.Warnings)
+	}
+
+	cfg.Transliteration = TransliterationError
+	_, err = BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "東京app"})
+	var componentErr *ComponentError
+	if !errors.As(err, &componentErr) || componentErr.Component != "qualifier" {
+		t.Fatalf("expected qualifier component error, got %v", err)
+	}
+}
+
+func TestBuildNameReportsUniquenessScope(t *testing.T) {
+	result, err := BuildName(Config{Cloud: CloudAWS, OrgPrefix: "acme", Env: "dev", Region: "us-east-1", IgnoreRegionForRegionalResources: true}, BuildInput{Resource: "sqs"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Scope != ScopeRegion || result.ScopeKey != "use1" {
+		t.Fatalf("expected region scope keyed by %q, got %q/%q", "use1", result.Scope, result.ScopeKey)
+	}
+
+	result, err = BuildName(Config{Cloud: CloudAWS, OrgPrefix: "acme", Env: "dev", Region: "us-east-1"}, BuildInput{Resource: "iam_role"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Scope != ScopeGlobal || result.ScopeKey != "" {
+		t.Fatalf("expected unkeyed global scope, got %q/%q", result.Scope, result.ScopeKey)
+	}
+
+	result, err = BuildName(Config{Cloud: CloudAzure, OrgPrefix: "acme", Env: "dev", Region: "westeurope"}, BuildInput{Resource: "azurerm_virtual_network", ScopeKey: "rg-core"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Scope != ScopeResourceGroup || result.ScopeKey != "rg-core" {
+		t.Fatalf("expected resource group scope keyed by %q, got %q/%q", "rg-core", result.Scope, result.ScopeKey)
+	}
+}
+
+func TestBuildNameChildOfParentScopedResourceDropsSharedComponents(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAzure,
+		OrgPrefix: "acme",
+		Project:   "payments",
+		Env:       "prod",
+		Region:    "westeurope",
+	}
+
+	vnet, err := BuildName(cfg, BuildInput{Resource: "azurerm_virtual_network", Qualifier: "core"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+
+	subnet, err := BuildName(cfg, BuildInput{Resource: "azurerm_subnet", Qualifier: "app", Parent: vnet.Components})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if subnet.Name != "snet-app" {
+		t.Fatalf("expected shared components to be dropped, got %q", subnet.Name)
+	}
+	if subnet.ParentMode != ParentModeDrop {
+		t.Fatalf("expected auto parent mode to resolve to %q, got %q", ParentModeDrop, subnet.ParentMode)
+	}
+	if subnet.Components["org"] != "acme" {
+		t.Fatalf("expected dropped components to stay in components output, got %v", subnet.Components)
+	}
+
+	otherVnet, err := BuildName(cfg, BuildInput{Resource: "azurerm_virtual_network", Qualifier: "edge"})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	otherSubnet, err := BuildName(cfg, BuildInput{Resource: "azurerm_subnet", Qualifier: "app", Parent: otherVnet.Components})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if otherSubnet.Name != subnet.Name || otherSubnet.ScopeKey == subnet.ScopeKey {
+		t.Fatalf("expected equal names under distinct parent scope keys, got %q/%q and %q/%q", subnet.Name, subnet.ScopeKey, otherSubnet.Name, otherSubnet.ScopeKey)
+	}
+}
+
+func TestBuildNameReusesParentComponents(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		Recipe:    []string{"org", "env", "resource", "qualifier"},
+	}
+	parent := map[string]string{"org": "acme", "env": "prod", "resource": "eksc", "qualifier": "main"}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "jobs", Parent: parent})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "acme-prod-sqs-jobs" {
+		t.Fatalf("expected parent env to be reused, got %q", result.Name)
+	}
+
+	result, err = BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "jobs", Parent: parent, ParentMode: ParentModeDrop})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+	if result.Name != "dev-sqs-jobs" {
+		t.Fatalf("expected only the shared org to be dropped, got %q", result.Name)
+	}
+
+	if _, err := BuildName(cfg, BuildInput{Resource: "sqs", Parent: parent, ParentMode: "inherit"}); err == nil || !strings.Contains(err.Error(), "unsupported parent_mode") {
+		t.Fatalf("expected invalid parent_mode error, got %v", err)
+	}
+}
+
+func TestBuildSequenceIndexesNames(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		Recipe:    []string{"org", "env", "resource", "index", "qualifier"},
+	}
+
+	results, err := BuildSequence(cfg, BuildInput{Resource: "msk_cluster", Qualifier: "broker"}, SequenceInput{Count: 3, Start: 1, Padding: 2})
+	if err != nil {
+		t.Fatalf("unexpected sequence error: %v", err)
+	}
+	expected := []string{"acme-dev-mskc-01-broker", "acme-dev-mskc-02-broker", "acme-dev-mskc-03-broker"}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d names, got %d", len(expected), len(results))
+	}
+	for i, result := range results {
+		if result.Name != expected[i] {
+			t.Fatalf("expected name %d to be %q, got %q", i, expected[i], result.Name)
+		}
+	}
+	if results[0].RecipeSource != RecipeSourceProvider {
+		t.Fatalf("expected recipe source %q, got %q", RecipeSourceProvider, results[0].RecipeSource)
+	}
+
+	cfg.Recipe = []string{"org", "env", "resource"}
+	results, err = BuildSequence(cfg, BuildInput{Resource: "sqs"}, SequenceInput{Count: 2, Start: 9, Padding: 1})
+	if err != nil {
+		t.Fatalf("unexpected sequence error: %v", err)
+	}
+	if results[0].Name != "acme-dev-sqs-9" || results[1].Name != "acme-dev-sqs-10" {
+		t.Fatalf("expected index appended to the recipe, got %q and %q", results[0].Name, results[1].Name)
+	}
+}
+
+func TestBuildSequenceFailsOnWidestIndex(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		Recipe:    []string{"org", "env", "qualifier", "index"},
+		ResourceConstraints: map[string]ResourceConstraint{
+			"sqs": {MaxLen: 15},
+		},
+	}
+
+	_, err := BuildSequence(cfg, BuildInput{Resource: "sqs", Qualifier: "jobs"}, SequenceInput{Count: 100, Start: 1, Padding: 1})
+	if err == nil || !strings.Contains(err.Error(), "sequence index 100:") || !strings.Contains(err.Error(), "exceeds 15 characters") {
+		t.Fatalf("expected widest index to fail first, got %v", err)
+	}
+
+	if _, err := BuildSequence(cfg, BuildInput{Resource: "sqs"}, SequenceInput{Count: 0}); err == nil {
+		t.Fatal("expected error for zero count")
+	}
+}
+
+func TestAWSResourceKeysForTheSameTypeBuildTheSameName(t *testing.T) {
+	cfg := Config{Cloud: CloudAWS, OrgPrefix: "acme", Env: "dev", Region: "eu-west-1"}
+	for _, keys := range [][2]string{{"sqs", "sqs_queue"}, {"sns", "sns_topic"}, {"sec_group", "security_group"}} {
+		first, err := BuildName(cfg, BuildInput{Resource: keys[0], Qualifier: "orders"})
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", keys[0], err)
+		}
+		second, err := BuildName(cfg, BuildInput{Resource: keys[1], Qualifier: "orders"})
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", keys[1], err)
+		}
+		if first.Name != second.Name || first.Scope != second.Scope {
+			t.Fatalf("expected %s and %s to match, got %q (%s) and %q (%s)", keys[0], keys[1], first.Name, first.Scope, second.Name, second.Scope)
+		}
+	}
+}
+
+func TestFindAcronymCollisionsIgnoresAliases(t *testing.T) {
+	defaults, err := DefaultCloudDefaults(CloudAWS)
+	if err != nil {
+		t.Fatalf("unexpected error loading AWS defaults: %v", err)
+	}
+	acronyms := defaults.ResourceAcronyms
+	acronyms["job_queue"] = "sqs"
+	acronyms["workflow"] = "stfn"
+
+	collisions := FindAcronymCollisions(acronyms, defaults.ResourceAliases, []string{"job_queue", "workflow", "iam_role"})
+	if len(collisions) != 2 {
+		t.Fatalf("expected one collision per alias group, got %v", collisions)
+	}
+	if collisions[0] != (AcronymCollision{Resource: "job_queue", Other: "sqs", Acronym: "sqs"}) {
+		t.Fatalf("unexpected first collision: %+v", collisions[0])
+	}
+	if collisions[1] != (AcronymCollision{Resource: "workflow", Other: "step_function", Acronym: "stfn"}) {
+		t.Fatalf("expected sfn to be reported through its root, got %+v", collisions[1])
+	}
+
+	aliases := defaults.ResourceAliases
+	aliases["workflow"] = "sfn"
+	collisions = FindAcronymCollisions(acronyms, aliases, []string{"workflow", "iam_role"})
+	if len(collisions) != 0 {
+		t.Fatalf("expected declared aliases not to collide, got %v", collisions)
+	}
+}
+
+func defaultResourceAcronyms(t *testing.T, cloud string) map[string]string {
+	t.Helper()
+	defaults, err := DefaultCloudDefaults(cloud)
+	if err != nil {
+		t.Fatalf("unexpected error loading %s defaults: %v", cloud, err)
+	}
+	return defaults.ResourceAcronyms
+}
+
+func TestResourceDefinitionsAreWellFormed(t *testing.T) {
+	for _, cloud := range []string{CloudAWS, CloudAzure, CloudGCP} {
+		definitions, err := ResourceDefinitions(cloud)
+		if err != nil {
+			t.Fatalf("unexpected error loading %s definitions: %v", cloud, err)
+		}
+		if len(definitions) == 0 {
+			t.Fatalf("expected %s definitions", cloud)
+		}
+		if cloud == CloudAzure {
+			continue
+		}
+
+		seen := map[string]bool{}
+		for _, definition := range definitions {
+			if seen[definition.Name] {
+				t.Fatalf("duplicate %s definition %q", cloud, definition.Name)
+			}
+			seen[definition.Name] = true
+			if definition.MaxLength > 0 && definition.MinLength > definition.MaxLength {
+				t.Fatalf("%s definition %q has min_length above max_length", cloud, definition.Name)
+			}
+			if _, err := regexp.Compile(definitionRegex(definition)); err != nil {
+				t.Fatalf("%s definition %q has invalid validation_regex: %v", cloud, definition.Name, err)
+			}
+			for _, pattern := range definition.ForbiddenPatterns {
+				if _, err := regexp.Compile(pattern); err != nil {
+					t.Fatalf("%s definition %q has invalid forbidden pattern: %v", cloud, definition.Name, err)
+				}
+			}
+			if definition.TerraformType != "" && definition.SourceURL == "" {
+				t.Fatalf("%s definition %q has no source_url", cloud, definition.Name)
+			}
+		}
+	}
+}
+
+func TestLoadResourceDefinitionsHonorsRegionalOverride(t *testing.T) {
+	defaults, err := DefaultCloudDefaults(CloudAWS)
+	if err != nil {
+		t.Fatalf("unexpected error loading AWS defaults: %v", err)
+	}
+	if defaults.ResourceScopes["route53_record"] != ScopeParent {
+		t.Fatalf("expected route53_record to be parent scoped, got %q", defaults.ResourceScopes["route53_record"])
+	}
+	if defaults.RegionalResources["route53_record"] {
+		t.Fatal("expected route53_record to stay global despite its parent scope")
+	}
+	if !defaults.RegionalResources["s3_object"] {
+		t.Fatal("expected s3_object to be regional")
+	}
+	if _, ok := defaults.ResourceStyleOverrides["lambda"]; ok {
+		t.Fatal("expected no style override for lambda")
+	}
+}
+
+func TestCatalogFiltersByPrefixAndTag(t *testing.T) {
+	entries, err := Catalog(Config{Cloud: CloudAWS}, CatalogFilter{Prefix: "s3", Tags: []string{CatalogTagConstrained}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Resource != "s3" || entries[1].Resource != "s3_bucket" {
+		t.Fatalf("expected s3 and s3_bucket, got %+v", entries)
+	}
+	bucket := entries[1]
+	if bucket.Acronym != "s3bk" || bucket.MinLen != 3 || bucket.MaxLen != 63 || !bucket.Regional {
+		t.Fatalf("unexpected s3_bucket entry: %+v", bucket)
+	}
+	if strings.Join(bucket.Styles, ",") != "dashed,straight" {
+		t.Fatalf("expected restricted styles, got %v", bucket.Styles)
+	}
+	if strings.Join(bucket.Tags, ",") != "constrained,region,regional,styled" {
+		t.Fatalf("unexpected tags: %v", bucket.Tags)
+	}
+
+	entries, err = Catalog(Config{Cloud: CloudGCP}, CatalogFilter{Prefix: "google_container_", Tags: []string{ScopeParent}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Resource != "container_node_pool" {
+		t.Fatalf("expected container_node_pool, got %+v", entries)
+	}
+
+	entries, err = Catalog(Config{Cloud: CloudAWS}, CatalogFilter{Tags: []string{CatalogTagZonal}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zonal := []string{}
+	for _, entry := range entries {
+		if !entry.Zonal {
+			t.Fatalf("expected only zonal entries, got %+v", entry)
+		}
+		zonal = append(zonal, entry.Resource)
+	}
+	if !containsString(zonal, "subnet") || containsString(zonal, "s3_bucket") {
+		t.Fatalf("expected zonal entries to follow the zonal resources, got %v", zonal)
+	}
+}
+
+func TestCatalogUsesConfiguredAcronyms(t *testing.T) {
+	defaults, err := DefaultCloudDefaults(CloudAWS)
+	if err != nil {
+		t.Fatalf("unexpected error loading AWS defaults: %v", err)
+	}
+	defaults.ResourceAcronyms["job_queue"] = "jobq"
+	entries, err := Catalog(Config{Cloud: CloudAWS, ResourceAcronyms: defaults.ResourceAcronyms}, CatalogFilter{Prefix: "job_"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Acronym != "jobq" || entries[0].Scope != ScopeGlobal {
+		t.Fatalf("expected custom job_queue entry, got %+v", entries)
+	}
+	if len(entries[0].Styles) != len(DefaultStylePriority()) {
+		t.Fatalf("expected unrestricted styles, got %v", entries[0].Styles)
+	}
+}
+
+func TestCompileNormalizesResourceKeys(t *testing.T) {
+	compiled, err := Compile(Config{
+		Cloud:            CloudAWS,
+		OrgPrefix:        "acme",
+		Env:              "dev",
+		Region:           "EU West 1",
+		ResourceAcronyms: map[string]string{" Queue ": "q", "queue": "queue"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := compiled.BuildName(BuildInput{Resource: "queue"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-euw1-queue" {
+		t.Fatalf("expected acme-dev-euw1-queue, got %q", result.Name)
+	}
+	if compiled.RegionCode() != "euw1" {
+		t.Fatalf("expected region code euw1, got %q", compiled.RegionCode())
+	}
+}
+
+func TestCompiledMatchesBuildName(t *testing.T) {
+	cfg := Config{Cloud: CloudAWS, OrgPrefix: "acme", Project: "shop", Env: "prod", Region: "us-east-1"}
+	compiled, err := Compile(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, resource := range []string{"s3", "sqs", "lambda", "route53_record", "custom"} {
+		in := BuildInput{Resource: resource, Qualifier: "orders"}
+		want, err := BuildName(cfg, in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", resource, err)
+		}
+		got, err := compiled.BuildName(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", resource, err)
+		}
+		if got.Name != want.Name || got.DisplayName != want.DisplayName {
+			t.Fatalf("%s: expected %q (%q), got %q (%q)", resource, want.Name, want.DisplayName, got.Name, got.DisplayName)
+		}
+	}
+}
+
+func TestRegionIndexReverseLookupIsStable(t *testing.T) {
+	index := newRegionIndex(map[string]string{"us-east-1": "use1", "US East 1": "use1", "eu-west-1": "euw1"}, nil)
+	if got := index.region("USE1"); got != "US East 1" {
+		t.Fatalf("expected the first region in sort order, got %q", got)
+	}
+	if got := index.lookup("us_east_1"); got != "use1" {
+		t.Fatalf("expected use1, got %q", got)
+	}
+}
+
+const benchmarkBatchSize = 1000
+
+func benchmarkInputs() []BuildInput {
+	resources := []string{"s3", "sqs", "sns", "lambda", "dynamodb", "ecr", "kms", "iam_role"}
+	inputs := make([]BuildInput, benchmarkBatchSize)
+	for i := range inputs {
+		inputs[i] = BuildInput{Resource: resources[i%len(resources)], Qualifier: "svc" + strings.Repeat("x", i%7)}
+	}
+	return inputs
+}
+
+func BenchmarkBuildNameBatch(b *testing.B) {
+	cfg := Config{Cloud: CloudAWS, OrgPrefix: "acme", Project: "shop", Env: "prod", Region: "eu-west-1"}
+	inputs := benchmarkInputs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, in := range inputs {
+			if _, err := BuildName(cfg, in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCompiledBuildNameBatch(b *testing.B) {
+	cfg := Config{Cloud: CloudAWS, OrgPrefix: "acme", Project: "shop", Env: "prod", Region: "eu-west-1"}
+	inputs := benchmarkInputs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		compiled, err := Compile(cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, in := range inputs {
+			if _, err := compiled.BuildName(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestParseNameRoundTrip(t *testing.T) {
+	compiled, err := Compile(Config{Cloud: CloudAWS, OrgPrefix: "acme", Project: "shop", Env: "prod", Region: "eu-west-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		resource  string
+		qualifier string
+	}{
+		{resource: "sqs", qualifier: "orders"},
+		{resource: "s3", qualifier: "order-events"},
+		{resource: "iam_role", qualifier: ""},
+		{resource: "dynamodb", qualifier: "sessions"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.resource, func(t *testing.T) {
+			built, err := compiled.BuildName(BuildInput{Resource: tc.resource, Qualifier: tc.qualifier})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			parsed, err := compiled.ParseName(tc.resource, built.Name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if parsed.Style != built.Style {
+				t.Fatalf("expected style %q, got %q", built.Style, parsed.Style)
+			}
+			for _, key := range []string{"org", "proj", "env", "region", "resource"} {
+				if built.Components[key] != "" && !strings.EqualFold(parsed.Components[key], built.Components[key]) {
+					t.Fatalf("component %s: expected %q, got %q", key, built.Components[key], parsed.Components[key])
+				}
+			}
+			if got, want := parsed.Components["qualifier"], strings.ReplaceAll(tc.qualifier, "-", styleSeparator(built.Style)); got != want {
+				t.Fatalf("expected qualifier %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestParseNameRejectsForeignNames(t *testing.T) {
+	compiled, err := Compile(Config{Cloud: CloudAWS, OrgPrefix: "acme", Env: "prod", Region: "eu-west-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := compiled.ParseName("sqs", "other-prod-euw1-sqs-orders"); err == nil || !strings.Contains(err.Error(), "org component") {
+		t.Fatalf("expected org mismatch, got %v", err)
+	}
+	if _, err := compiled.ParseName("sqs", "acme-prod"); err == nil {
+		t.Fatal("expected a short name to fail")
+	}
+}
+
+func TestParseNameSplitsFreeComponents(t *testing.T) {
+	compiled, err := Compile(Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "prod",
+		Recipe:    []string{"org", "env", "resource", "qualifier", "index"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parsed, err := compiled.ParseName("sqs", "acme-prod-sqs-order-events-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parsed.Components["qualifier"] != "order-events" || parsed.Components["index"] != "01" {
+		t.Fatalf("unexpected components: %v", parsed.Components)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	compiled, err := Compile(Config{Cloud: CloudAWS})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := compiled.ValidateName("s3", "acme-prod-assets"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var constraintErr *ConstraintError
+	if err := compiled.ValidateName("s3", "Acme_Assets"); !errors.As(err, &constraintErr) {
+		t.Fatalf("expected ConstraintError, got %v", err)
+	}
+}
+
+func TestEnforcementLevels(t *testing.T) {
+	base := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		ResourceConstraints: map[string]ResourceConstraint{
+			"queue": {MaxLen: 10, Pattern: regexp.MustCompile(`^[a-z-]+$`)},
+		},
+	}
+	in := BuildInput{Resource: "queue", Qualifier: "orders2"}
+
+	if _, err := BuildName(base, in); err == nil {
+		t.Fatal("expected the default level to fail")
+	}
+
+	warn := base
+	warn.Enforcement = EnforcementWarn
+	result, err := BuildName(warn, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-queue-orders2" {
+		t.Fatalf("expected the name to be built, got %q", result.Name)
+	}
+	if len(result.Violations) != 2 || result.Violations[0].Category != ConstraintLength || result.Violations[1].Category != ConstraintPattern {
+		t.Fatalf("expected length and pattern violations, got %+v", result.Violations)
+	}
+
+	// A category override wins over the configured level.
+	mixed := warn
+	mixed.EnforcementOverrides = map[string]string{"pattern": EnforcementError}
+	var constraintErr *ConstraintError
+	if _, err := BuildName(mixed, in); !errors.As(err, &constraintErr) || constraintErr.Category != ConstraintPattern {
+		t.Fatalf("expected a pattern error, got %v", err)
+	}
+
+	off := base
+	off.Enforcement = EnforcementOff
+	result, err = BuildName(off, in)
+	if err != nil || len(result.Violations) != 0 {
+		t.Fatalf("expected no violations, got %v %+v", err, result.Violations)
+	}
+}
+
+func TestEnforcementStyleFallback(t *testing.T) {
+	cfg := Config{
+		Cloud:                  CloudAWS,
+		OrgPrefix:              "acme",
+		Env:                    "dev",
+		StylePriority:          []string{StyleDashed},
+		ResourceStyleOverrides: map[string][]string{"queue": {StylePascal}},
+	}
+	in := BuildInput{Resource: "queue"}
+
+	result, err := BuildName(cfg, in)
+	if err != nil || len(result.Violations) != 0 {
+		t.Fatalf("expected style fallbacks to be off by default, got %v %+v", err, result.Violations)
+	}
+
+	cfg.EnforcementOverrides = map[string]string{"style": EnforcementWarn}
+	result, err = BuildName(cfg, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Violations) != 1 || result.Violations[0].Category != ConstraintStyle || result.Style != StylePascal {
+		t.Fatalf("expected a style warning, got %q %+v", result.Style, result.Violations)
+	}
+
+	cfg.EnforcementOverrides = map[string]string{"style": EnforcementError}
+	if _, err := BuildName(cfg, in); err == nil || !strings.Contains(err.Error(), "style priority") {
+		t.Fatalf("expected a style error, got %v", err)
+	}
+}
+
+func TestEnforcementRejectsUnknownValues(t *testing.T) {
+	if _, err := Compile(Config{Cloud: CloudAWS, Enforcement: "loud"}); err == nil {
+		t.Fatal("expected an invalid level to fail")
+	}
+	if _, err := Compile(Config{Cloud: CloudAWS, EnforcementOverrides: map[string]string{"size": EnforcementWarn}}); err == nil {
+		t.Fatal("expected an invalid category to fail")
+	}
+}
+
+func TestDeniedWords(t *testing.T) {
+	cfg := Config{
+		Cloud:          CloudAWS,
+		OrgPrefix:      "acme",
+		Env:            "dev",
+		DeniedWords:    []string{"Falcon", "globex corp"},
+		DeniedPatterns: []*regexp.Regexp{regexp.MustCompile(`initech\d*`)},
+	}
+
+	cases := []struct {
+		qualifier string
+		denied    string
+	}{
+		{qualifier: "falcon-api", denied: `denied word "Falcon"`},
+		{qualifier: "FALCON", denied: `denied word "Falcon"`},
+		{qualifier: "globex-corp-sync", denied: `denied word "globex corp"`},
+		{qualifier: "initech42", denied: `denied pattern "initech\\d*"`},
+		{qualifier: "falconry"},
+		{qualifier: "globex"},
+		{qualifier: "myinitech"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.qualifier, func(t *testing.T) {
+			_, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: tc.qualifier})
+			if tc.denied == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var constraintErr *ConstraintError
+			if !errors.As(err, &constraintErr) || constraintErr.Category != ConstraintForbidden || !strings.Contains(err.Error(), tc.denied) {
+				t.Fatalf("expected %s, got %v", tc.denied, err)
+			}
+		})
+	}
+
+	// Words are matched on the split parts, so straight names are covered too.
+	straight := cfg
+	straight.StylePriority = []string{StyleStraight}
+	if _, err := BuildName(straight, BuildInput{Resource: "sqs", Qualifier: "falcon"}); err == nil {
+		t.Fatal("expected a straight name with a denied part to fail")
+	}
+
+	warn := cfg
+	warn.EnforcementOverrides = map[string]string{"forbidden": EnforcementWarn}
+	result, err := BuildName(warn, BuildInput{Resource: "sqs", Qualifier: "falcon"})
+	if err != nil || len(result.Violations) != 1 {
+		t.Fatalf("expected a warning, got %v %+v", err, result.Violations)
+	}
+}
+
+func TestReservedWords(t *testing.T) {
+	cfg := Config{Cloud: CloudAzure, OrgPrefix: "acme", Env: "dev"}
+	in := BuildInput{Resource: "azurerm_resource_group", Qualifier: "office"}
+
+	if _, err := BuildName(cfg, in); err != nil {
+		t.Fatalf("expected reserved words to be opt-in, got %v", err)
+	}
+
+	cfg.DenyReservedWords = true
+	if _, err := BuildName(cfg, in); err == nil || !strings.Contains(err.Error(), "reserved by azure") {
+		t.Fatalf("expected a reserved word error, got %v", err)
+	}
+
+	compiled, err := Compile(Config{Cloud: CloudGCP, DenyReservedWords: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := compiled.ValidateName("google_storage_bucket", "acme-google-assets"); err == nil {
+		t.Fatal("expected validation to apply reserved words")
+	}
+}
+
+func TestHashComponent(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		Recipe:    []string{"org", "env", "resource", "qualifier", "hash"},
+	}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "orders"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sum := sha256.Sum256([]byte("acme-dev-sqs-orders"))
+	want := hex.EncodeToString(sum[:])[:DefaultHashLength]
+	if result.Name != "acme-dev-sqs-orders-"+want || result.Components[HashComponent] != want {
+		t.Fatalf("expected hash %q, got %q", want, result.Name)
+	}
+
+	inputs := cfg
+	inputs.HashInputs = []string{"org", "qualifier"}
+	inputs.HashEncoding = HashEncodingBase32
+	inputs.HashLength = 8
+	result, err = BuildName(inputs, BuildInput{Resource: "sqs", Qualifier: "orders"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sum = sha256.Sum256([]byte("acme/orders"))
+	want = strings.ToLower(base32.StdEncoding.EncodeToString(sum[:]))[:8]
+	if result.Components[HashComponent] != want {
+		t.Fatalf("expected hash %q, got %q", want, result.Components[HashComponent])
+	}
+
+	result, err = BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "orders", Overrides: map[string]string{"hash": "fixed"}})
+	if err != nil || result.Name != "acme-dev-sqs-orders-fixed" {
+		t.Fatalf("expected the override to replace the hash, got %q %v", result.Name, err)
+	}
+
+	compiled, err := Compile(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := compiled.ParseName("sqs", "acme-dev-sqs-orders-sync-ab12cd")
+	if err != nil || parsed.Components["qualifier"] != "orders-sync" || parsed.Components[HashComponent] != "ab12cd" {
+		t.Fatalf("unexpected parse %+v %v", parsed, err)
+	}
+}
+
+func TestHashComponentFollowsNamePattern(t *testing.T) {
+	cfg := Config{
+		Cloud:     CloudAWS,
+		OrgPrefix: "acme",
+		Env:       "dev",
+		Recipe:    []string{"org", "env", "resource", "hash"},
+		ResourceAcronyms: map[string]string{
+			"widget": "wdg",
+		},
+		ResourceConstraints: map[string]ResourceConstraint{
+			"widget": {Pattern: regexp.MustCompile(`^[a-z-]+$`)},
+		},
+		HashLength: 10,
+	}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "widget"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hash := result.Components[HashComponent]
+	if len(hash) != 10 || strings.Trim(hash, "abcdef") != "" {
+		t.Fatalf("expected 10 hex letters, got %q", hash)
+	}
+}
+
+func TestHashSettingsAreValidated(t *testing.T) {
+	cases := []Config{
+		{HashAlgorithm: "crc32"},
+		{HashEncoding: "base64"},
+		{HashLength: 2},
+		{HashLength: MaxHashLength + 1},
+		{HashInputs: []string{"hash"}},
+	}
+	for _, cfg := range cases {
+		cfg.Cloud = CloudAWS
+		if _, err := Compile(cfg); err == nil {
+			t.Fatalf("expected %+v to be rejected", cfg)
+		}
+	}
+}
+
+func TestAccountComponents(t *testing.T) {
+	cfg := Config{
+		Cloud:        CloudAWS,
+		OrgPrefix:    "acme",
+		Env:          "dev",
+		Account:      "123456789012",
+		Subscription: "0f1e2d3c-aaaa-bbbb-cccc-000000000000",
+		AccountMap: map[string]string{
+			"1234-5678-9012": "core",
+			"210987654321":   "sandbox",
+		},
+		Recipe: []string{"org", "aws_account_id", "env", "resource", "qualifier"},
+	}
+
+	result, err := BuildName(cfg, BuildInput{Resource: "s3", Qualifier: "logs"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-core-dev-s3b-logs" {
+		t.Fatalf("expected acme-core-dev-s3b-logs, got %q", result.Name)
+	}
+	if result.Components["account"] != "core" || result.Components["subscription"] != "0f1e2d3c-aaaa-bbbb-cccc-000000000000" || result.Components["gcp_project"] != "" {
+		t.Fatalf("unexpected account components %v", result.Components)
+	}
+
+	result, err = BuildName(cfg, BuildInput{Resource: "s3", Qualifier: "logs", Overrides: map[string]string{"account_id": "210987654321"}})
+	if err != nil || result.Name != "acme-sandbox-dev-s3b-logs" {
+		t.Fatalf("expected the overridden ID to be mapped, got %q %v", result.Name, err)
+	}
+
+	for key, want := range map[string]string{
+		"account_id":         "account",
+		"azure_subscription": "subscription",
+		"project_number":     "gcp_project",
+		"project":            "proj",
+	} {
+		if got := canonicalComponentKey(key); got != want {
+			t.Fatalf("canonicalComponentKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestResourceAffixes(t *testing.T) {
+	cfg := Config{Cloud: CloudAWS, OrgPrefix: "acme", Env: "dev"}
+
+	cases := []struct {
+		resource string
+		fifo     bool
+		want     string
+	}{
+		{resource: "kms_alias", want: "alias/acme-dev-kms-alias-orders"},
+		{resource: "lambda_log_group", want: "/aws/lambda/acme-dev-lmbd-orders"},
+		{resource: "sqs", fifo: true, want: "acme-dev-sqs-orders.fifo"},
+		{resource: "sns", want: "acme-dev-sns-orders"},
+	}
+	for _, tc := range cases {
+		result, err := BuildName(cfg, BuildInput{Resource: tc.resource, Qualifier: "orders", Fifo: tc.fifo})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.resource, err)
+		}
+		if result.Name != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.resource, tc.want, result.Name)
+		}
+	}
+
+	if _, err := BuildName(cfg, BuildInput{Resource: "s3", Qualifier: "orders", Fifo: true}); err == nil {
+		t.Fatal("expected fifo to fail for a resource without a FIFO variant")
+	}
+
+	// The suffix counts toward MaxLen: a 76-character SQS name fits, but not
+	// once .fifo is added.
+	qualifier := strings.Repeat("q", 80-len("acme-dev-sqs-")-len(FifoSuffix)+1)
+	if _, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: qualifier}); err != nil {
+		t.Fatalf("unexpected error without fifo: %v", err)
+	}
+	_, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: qualifier, Fifo: true})
+	var constraintErr *ConstraintError
+	if !errors.As(err, &constraintErr) || constraintErr.Category != ConstraintLength {
+		t.Fatalf("expected a length violation, got %v", err)
+	}
+
+	compiled, err := Compile(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := compiled.ParseName("sqs", "acme-dev-sqs-orders.fifo")
+	if err != nil || parsed.Components["qualifier"] != "orders" {
+		t.Fatalf("unexpected parse %+v %v", parsed, err)
+	}
+	if _, err := compiled.ParseName("kms_alias", "acme-dev-kms-alias-orders"); err == nil {
+		t.Fatal("expected a name without the fixed prefix to be rejected")
+	}
+}
+
+func TestMultiRegionNames(t *testing.T) {
+	cfg := Config{
+		Cloud:                            CloudGCP,
+		OrgPrefix:                        "acme",
+		Env:                              "dev",
+		Region:                           "europe-west1",
+		IgnoreRegionForRegionalResources: true,
+		RegionalResources:                map[string]bool{"gcs": true},
+		DisplayRecipe:                    []string{"region"},
+	}
+
+	cases := []struct {
+		name    string
+		cfg     func(Config) Config
+		regions []string
+		want    string
+		display string
+	}{
+		{name: "single region dropped", want: "acme-dev-gcs-logs"},
+		{name: "regions list", regions: []string{"europe-west1", "europe-west4", "europe-west1"}, want: "acme-dev-euw1-euw4-gcs-logs", display: "Europe West 1 / Europe West 4"},
+		{name: "multi-region location", regions: []string{"EU"}, want: "acme-dev-eu-gcs-logs"},
+		{name: "dual-region location", cfg: func(c Config) Config { c.Region = "eur4"; return c }, want: "acme-dev-eur4-gcs-logs"},
+		{name: "configured regions", cfg: func(c Config) Config { c.Regions = []string{"us-east1", "us-central1"}; return c }, want: "acme-dev-usc1-use1-gcs-logs"},
+		{name: "regions in any order", regions: []string{"europe-west4", "europe-west1"}, want: "acme-dev-euw1-euw4-gcs-logs", display: "Europe West 1 / Europe West 4"},
+		{name: "short code wins", cfg: func(c Config) Config { c.RegionShortCode = "euro"; return c }, regions: []string{"europe-west1", "europe-west4"}, want: "acme-dev-euro-gcs-logs"},
+	}
+	for _, tc := range cases {
+		c := cfg
+		if tc.cfg != nil {
+			c = tc.cfg(c)
+		}
+		result, err := BuildName(c, BuildInput{Resource: "gcs", Qualifier: "logs", Regions: tc.regions})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if result.Name != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, result.Name)
+		}
+		if tc.display != "" && result.DisplayName != tc.display {
+			t.Fatalf("%s: expected display name %q, got %q", tc.name, tc.display, result.DisplayName)
+		}
+	}
+
+	compiled, err := Compile(Config{Cloud: CloudAWS, Regions: []string{"eu-west-1", "us-east-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := compiled.RegionCode(); got != "euw1-use1" {
+		t.Fatalf("expected combined region code, got %q", got)
+	}
+}
+
+func TestStrictRegions(t *testing.T) {
+	cfg := Config{Cloud: CloudAWS, OrgPrefix: "acme", Env: "dev", Region: "eu-wset-1"}
+
+	lenient, err := Compile(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unknown := lenient.UnknownRegions(); len(unknown) != 1 || unknown[0].Error() != `unknown region "eu-wset-1"; did you mean "eu-west-1"?` {
+		t.Fatalf("expected one unknown configured region, got %v", unknown)
+	}
+	result, err := lenient.BuildName(BuildInput{Resource: "s3", Qualifier: "logs"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-eu-wset-1-s3b-logs" {
+		t.Fatalf("expected the raw region in the name, got %q", result.Name)
+	}
+	if len(result.Warnings) != 0 || len(result.UnknownRegions) != 0 {
+		t.Fatalf("expected the configured region to be reported once, not per name: %q %v", result.Warnings, result.UnknownRegions)
+	}
+	result, err = lenient.BuildName(BuildInput{Resource: "s3", Regions: []string{"eu-west-1", "us-esat-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.UnknownRegions) != 1 || result.UnknownRegions[0].Suggestion != "us-east-1" {
+		t.Fatalf("expected the per-name region to be reported, got %v", result.UnknownRegions)
+	}
+
+	cfg.StrictRegions = true
+	_, err = Compile(cfg)
+	var unknown *UnknownRegionError
+	if !errors.As(err, &unknown) || unknown.Region != "eu-wset-1" || unknown.Suggestion != "eu-west-1" {
+		t.Fatalf("expected an unknown region error with a suggestion, got %v", err)
+	}
+
+	cfg.Region = "EU West 1"
+	compiled, err := Compile(cfg)
+	if err != nil {
+		t.Fatalf("expected a normalized region to be known, got %v", err)
+	}
+	_, err = compiled.BuildName(BuildInput{Resource: "s3", Regions: []string{"eu-west-1", "mars-north-1"}})
+	if !errors.As(err, &unknown) || unknown.Suggestion != "" {
+		t.Fatalf("expected an unknown region error without a suggestion, got %v", err)
+	}
+
+	cfg.Region = "atlantis"
+	cfg.RegionShortCode = "atl"
+	if _, err := Compile(cfg); err != nil {
+		t.Fatalf("expected region_short_code to skip the check, got %v", err)
+	}
+}
+
+func TestDisplayConstraintEnforcement(t *testing.T) {
+	cfg := Config{Cloud: CloudGCP, OrgPrefix: "acme", Env: "prod"}
+	in := BuildInput{Resource: "project", Qualifier: "payments-gateway-x", DisplayRecipe: []string{"org", "env", "qualifier", "qualifier"}}
+
+	for _, tc := range []struct {
+		enforcement string
+		overrides   map[string]string
+		want        int
+	}{
+		{enforcement: EnforcementError, want: 1},
+		{enforcement: EnforcementWarn, want: 1},
+		{enforcement: EnforcementOff, want: 0},
+		{enforcement: EnforcementWarn, overrides: map[string]string{ConstraintLength: EnforcementOff}, want: 0},
+	} {
+		c := cfg
+		c.Enforcement = tc.enforcement
+		c.EnforcementOverrides = tc.overrides
+		result, err := BuildName(c, in)
+		if err != nil {
+			t.Fatalf("%s: expected the name to be built, got %v", tc.enforcement, err)
+		}
+		if result.Name != "acme-prod-project-payments-gateway-x" {
+			t.Fatalf("%s: unexpected name %q", tc.enforcement, result.Name)
+		}
+		if len(result.Violations) != tc.want {
+			t.Fatalf("%s %v: expected %d display violations, got %+v", tc.enforcement, tc.overrides, tc.want, result.Violations)
+		}
+	}
+}
+
+func TestFillCloudDefaultsFillsEachMap(t *testing.T) {
+	defaults, err := DefaultCloudDefaults(CloudAWS)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Setting the core maps must not keep the later ones from their defaults.
+	cfg := Config{
+		Cloud:                  CloudAWS,
+		OrgPrefix:              "acme",
+		Env:                    "dev",
+		RegionMap:              defaults.RegionMap,
+		ResourceAcronyms:       defaults.ResourceAcronyms,
+		ResourceStyleOverrides: defaults.ResourceStyleOverrides,
+		ResourceConstraints:    defaults.ResourceConstraints,
+		RegionalResources:      defaults.RegionalResources,
+	}
+	result, err := BuildName(cfg, BuildInput{Resource: "sqs", Qualifier: "orders", Fifo: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-sqs-orders.fifo" {
+		t.Fatalf("expected acme-dev-sqs-orders.fifo, got %q", result.Name)
+	}
+}
+
+func TestBuildSequenceLimits(t *testing.T) {
+	cfg := Config{Cloud: CloudAWS, OrgPrefix: "acme", Env: "dev"}
+	in := BuildInput{Resource: "ec2_instance", Qualifier: "web"}
+
+	for _, seq := range []SequenceInput{
+		{Count: MaxSequenceCount + 1, Start: 1},
+		{Count: 3, Start: 1, Padding: MaxSequencePadding + 1},
+		{Count: 3, Start: math.MaxInt},
+		{Count: 3, Start: math.MaxInt - 1},
+	} {
+		if _, err := BuildSequence(cfg, in, seq); err == nil {
+			t.Fatalf("expected %+v to be rejected", seq)
+		}
+	}
+	results, err := BuildSequence(cfg, in, SequenceInput{Count: 1, Start: math.MaxInt})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("expected the largest start to fit one name, got %d %v", len(results), err)
+	}
+}
@@ -0,0 +1,108 @@
+package naming
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Parent modes control how a child name uses the components of its parent.
+const (
+	// ParentModeAuto drops shared components for parent-scoped resources and
+	// reuses them for everything else.
+	ParentModeAuto = "auto"
+	// ParentModeReuse copies the parent components into the child so both
+	// names stay consistent.
+	ParentModeReuse = "reuse"
+	// ParentModeDrop omits components the child shares with its parent from
+	// the name, since the parent already carries them.
+	ParentModeDrop = "drop"
+)
+
+// parentOwnComponents are never inherited from or dropped because of a parent.
+var parentOwnComponents = map[string]bool{
+	"resource":  true,
+	"qualifier": true,
+}
+
+// IsValidParentMode reports whether mode is a supported parent mode.
+func IsValidParentMode(mode string) bool {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", ParentModeAuto, ParentModeReuse, ParentModeDrop:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolveParentMode turns ParentModeAuto into a concrete mode based on scope.
+func resolveParentMode(mode, scope string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if !IsValidParentMode(mode) {
+		return "", fmt.Errorf("unsupported parent_mode %q; valid values are %q, %q, and %q", mode, ParentModeAuto, ParentModeReuse, ParentModeDrop)
+	}
+	if mode == "" || mode == ParentModeAuto {
+		if scope == ScopeParent {
+			return ParentModeDrop, nil
+		}
+		return ParentModeReuse, nil
+	}
+	return mode, nil
+}
+
+func canonicalParent(parent map[string]string) map[string]string {
+	out := make(map[string]string, len(parent))
+	for key, val := range parent {
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+		if key == "" || val == "" {
+			continue
+		}
+		out[canonicalComponentKey(key)] = val
+	}
+	return out
+}
+
+// inheritParentComponents copies parent components into components.
+// Components the child deliberately left empty, such as an omitted region,
+// stay empty.
+func inheritParentComponents(components, parent map[string]string) {
+	for key, val := range parent {
+		if parentOwnComponents[key] {
+			continue
+		}
+		if current, ok := components[key]; ok && current == "" {
+			continue
+		}
+		components[key] = val
+	}
+}
+
+// sharedParentComponents returns the component keys whose values match the parent.
+func sharedParentComponents(components, parent map[string]string) map[string]bool {
+	shared := map[string]bool{}
+	for key, val := range parent {
+		if parentOwnComponents[key] {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(components[key]), val) {
+			shared[key] = true
+		}
+	}
+	return shared
+}
+
+// parentScopeKey identifies a parent by its components so children of
+// different parents do not collide.
+func parentScopeKey(parent map[string]string) string {
+	keys := make([]string, 0, len(parent))
+	for key := range parent {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+parent[key])
+	}
+	return strings.Join(pairs, ",")
+}
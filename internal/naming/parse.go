@@ -0,0 +1,168 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsedName is a name split back into the components of its recipe.
+type ParsedName struct {
+	Resource   string
+	Style      string
+	Components map[string]string
+}
+
+// ValidateName checks name against the denylist and the constraint of
+// resource. It returns a *ConstraintError when the name breaks either. The
+// fixed prefix and suffix of the resource are not checked for denied words.
+func (c *Compiled) ValidateName(resource, name string) error {
+	resourceKeys := resourceLookupCandidates(c.cfg.Cloud, resource)
+	words := name
+	if affix, ok := lookupResourceAffix(resourceKeys, c.cfg.ResourceAffixes); ok {
+		if stripped, ok := affix.strip(name); ok {
+			words = stripped
+		}
+	}
+	if reason := c.denied.check(c.split.words(words), c.cfg.Cloud); reason != "" && len(resourceKeys) > 0 {
+		return constraintError(resourceKeys[0], name, ConstraintForbidden, reason)
+	}
+	return validateResourceConstraints(resourceKeys, name, c.cfg.ResourceConstraints, nil)
+}
+
+// ParseName splits name into the components of the recipe resource would
+// use. Components fixed by the configuration, such as org and env, must
+// match; the qualifier and custom components take the remaining words. When
+// a recipe has several free components the first one takes every word the
+// others do not need, and each of the others takes one. The hash takes one
+// word of any value. The fixed prefix and suffix of the resource must be
+// present and are not returned as components. Only styles with a separator
+// can be parsed.
+func (c *Compiled) ParseName(resource, name string) (ParsedName, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ParsedName{}, fmt.Errorf("name must not be empty")
+	}
+	reference, err := c.buildName(BuildInput{Resource: resource}, false)
+	if err != nil {
+		return ParsedName{}, err
+	}
+	sep := styleSeparator(reference.Style)
+	if sep == "" {
+		return ParsedName{}, fmt.Errorf("names in style %q have no separator and cannot be parsed", reference.Style)
+	}
+
+	resourceKeys := resourceLookupCandidates(c.cfg.Cloud, resource)
+	if affix, ok := lookupResourceAffix(resourceKeys, c.cfg.ResourceAffixes); ok {
+		stripped, ok := affix.strip(name)
+		if !ok {
+			return ParsedName{}, fmt.Errorf("name %q does not have the fixed prefix %q and suffix %q of %s", name, affix.Prefix, affix.Suffix, resource)
+		}
+		name = stripped
+	}
+
+	recipe, _ := resolveRecipe(nil, resourceKeys, c.cfg)
+	type recipeItem struct {
+		key   string
+		fixed []string
+		hash  bool
+	}
+	items := make([]recipeItem, 0, len(recipe))
+	fixedWords, free := 0, 0
+	for _, item := range recipe {
+		key := canonicalComponentKey(item)
+		if key == "" {
+			continue
+		}
+		if key == HashComponent {
+			items = append(items, recipeItem{key: key, hash: true})
+			fixedWords++
+			continue
+		}
+		value := reference.Components[key]
+		if value == "" {
+			if isBuiltinComponent(key) && key != "qualifier" {
+				continue
+			}
+			items = append(items, recipeItem{key: key})
+			free++
+			continue
+		}
+		formatted, err := formatName(reference.Style, []string{value}, c.split)
+		if err != nil {
+			return ParsedName{}, err
+		}
+		words := strings.Split(formatted, sep)
+		items = append(items, recipeItem{key: key, fixed: words})
+		fixedWords += len(words)
+	}
+
+	words := strings.Split(name, sep)
+	if len(words) < fixedWords {
+		return ParsedName{}, fmt.Errorf("name %q is too short for recipe %q", name, strings.Join(recipe, ", "))
+	}
+
+	parsed := ParsedName{
+		Resource:   strings.ToLower(strings.TrimSpace(resource)),
+		Style:      reference.Style,
+		Components: map[string]string{},
+	}
+	pos := 0
+	for _, item := range items {
+		if item.hash {
+			parsed.Components[item.key] = words[pos]
+			pos++
+			fixedWords--
+			continue
+		}
+		if item.fixed != nil {
+			end := pos + len(item.fixed)
+			if end > len(words) || !equalFoldWords(words[pos:end], item.fixed) {
+				return ParsedName{}, fmt.Errorf("name %q does not match the %s component %q", name, item.key, strings.Join(item.fixed, sep))
+			}
+			parsed.Components[item.key] = strings.Join(words[pos:end], sep)
+			pos = end
+			fixedWords -= len(item.fixed)
+			continue
+		}
+		free--
+		take := len(words) - pos - fixedWords - free
+		if take < 0 {
+			take = 0
+		}
+		parsed.Components[item.key] = strings.Join(words[pos:pos+take], sep)
+		pos += take
+	}
+	if pos != len(words) {
+		return ParsedName{}, fmt.Errorf("name %q has unexpected trailing words %q", name, strings.Join(words[pos:], sep))
+	}
+	return parsed, nil
+}
+
+func styleSeparator(style string) string {
+	switch style {
+	case StyleDashed, StylePascalDashed:
+		return "-"
+	case StyleUnderscore:
+		return "_"
+	default:
+		return ""
+	}
+}
+
+func isBuiltinComponent(key string) bool {
+	switch key {
+	case "org", "proj", "env", "region", "zone", "resource", "qualifier", "account", "subscription", "gcp_project":
+		return true
+	default:
+		return false
+	}
+}
+
+func equalFoldWords(words, want []string) bool {
+	for i := range want {
+		if !strings.EqualFold(words[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+package naming
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResourceDefinition describes one resource type in an embedded definition
+// document. The core fields follow the Azure CAF resource definition schema;
+// the optional fields carry rules that schema cannot express. TerraformBlock
+// names the nested block of TerraformType an entry describes, for entries
+// such as WAF rules that have no resource of their own.
+type ResourceDefinition struct {
+	Name                string   `json:"name"`
+	TerraformType       string   `json:"terraform_type,omitempty"`
+	TerraformBlock      string   `json:"terraform_block,omitempty"`
+	MinLength           int      `json:"min_length"`
+	MaxLength           int      `json:"max_length"`
+	ValidationRegex     string   `json:"validation_regex"`
+	PatternDescription  string   `json:"pattern_description,omitempty"`
+	Scope               string   `json:"scope"`
+	Regional            *bool    `json:"regional,omitempty"`
+	Slug                string   `json:"slug"`
+	Dashes              bool     `json:"dashes"`
+	Lowercase           bool     `json:"lowercase"`
+	Styles              []string `json:"styles,omitempty"`
+	ForbiddenPrefixes   []string `json:"forbidden_prefixes,omitempty"`
+	ForbiddenSuffixes   []string `json:"forbidden_suffixes,omitempty"`
+	ForbiddenSubstrings []string `json:"forbidden_substrings,omitempty"`
+	ForbiddenPatterns   []string `json:"forbidden_patterns,omitempty"`
+	DisallowIPAddress   bool     `json:"disallow_ip_address,omitempty"`
+	CaseInsensitive     bool     `json:"case_insensitive,omitempty"`
+	FixedPrefix         string   `json:"fixed_prefix,omitempty"`
+	FixedSuffix         string   `json:"fixed_suffix,omitempty"`
+	Fifo                bool     `json:"fifo,omitempty"`
+	SourceURL           string   `json:"source_url,omitempty"`
+}
+
+// resourceDefinitionSource is an embedded definition document.
+type resourceDefinitionSource struct {
+	label string
+	data  []byte
+	// deriveStyles fills style overrides from dashes and lowercase when a
+	// definition lists no styles. Azure CAF has no styles field.
+	deriveStyles bool
+}
+
+func resourceDefinitionSourceFor(cloud string) (resourceDefinitionSource, error) {
+	switch NormalizeCloud(cloud) {
+	case CloudAWS:
+		return resourceDefinitionSource{label: "AWS", data: awsResourceDefinitionJSON}, nil
+	case CloudAzure:
+		return resourceDefinitionSource{label: "Azure CAF", data: azureCAFResourceDefinitionJSON, deriveStyles: true}, nil
+	case CloudGCP:
+		return resourceDefinitionSource{label: "GCP", data: gcpResourceDefinitionJSON}, nil
+	default:
+		return resourceDefinitionSource{}, fmt.Errorf("unsupported cloud %q", cloud)
+	}
+}
+
+// ResourceDefinitions returns the embedded resource definitions for cloud.
+func ResourceDefinitions(cloud string) ([]ResourceDefinition, error) {
+	source, err := resourceDefinitionSourceFor(cloud)
+	if err != nil {
+		return nil, err
+	}
+	return source.decode()
+}
+
+func (s resourceDefinitionSource) decode() ([]ResourceDefinition, error) {
+	var definitions []ResourceDefinition
+	if err := json.Unmarshal(s.data, &definitions); err != nil {
+		return nil, fmt.Errorf("decode %s resource definitions: %w", s.label, err)
+	}
+	return definitions, nil
+}
+
+// loadResourceDefinitions builds the resource maps of CloudDefaults from an
+// embedded definition document. Region maps, zonal resources, display
+// constraints, and aliases are left to the caller.
+func loadResourceDefinitions(source resourceDefinitionSource) (CloudDefaults, error) {
+	definitions, err := source.decode()
+	if err != nil {
+		return CloudDefaults{}, err
+	}
+
+	acronyms := make(map[string]string, len(definitions))
+	styleOverrides := make(map[string][]string, len(definitions))
+	constraints := make(map[string]ResourceConstraint, len(definitions))
+	regionalResources := make(map[string]bool, len(definitions))
+	scopes := make(map[string]string, len(definitions))
+	affixes := map[string]ResourceAffix{}
+
+	for _, definition := range definitions {
+		name := strings.ToLower(strings.TrimSpace(definition.Name))
+		if name == "" {
+			continue
+		}
+
+		acronym := toLowerAlnum(definition.Slug)
+		if acronym != "" {
+			acronyms[name] = acronym
+		}
+		switch {
+		case len(definition.Styles) > 0:
+			styleOverrides[name] = append([]string{}, definition.Styles...)
+		case source.deriveStyles:
+			styleOverrides[name] = azureCAFStyleOverrides(definition.Lowercase, definition.Dashes)
+		}
+		if definition.Regional != nil {
+			regionalResources[name] = *definition.Regional
+		} else {
+			regionalResources[name] = azureCAFIsRegionalScope(definition.Scope)
+		}
+		scopes[name] = azureCAFScope(definition.Scope)
+		if definition.FixedPrefix != "" || definition.FixedSuffix != "" || definition.Fifo {
+			affixes[name] = ResourceAffix{Prefix: definition.FixedPrefix, Suffix: definition.FixedSuffix, Fifo: definition.Fifo}
+		}
+		if constraint, ok := definitionConstraint(source.label, definition); ok {
+			constraints[name] = constraint
+		}
+	}
+
+	return CloudDefaults{
+		ResourceAcronyms:       acronyms,
+		ResourceStyleOverrides: styleOverrides,
+		ResourceConstraints:    constraints,
+		RegionalResources:      regionalResources,
+		ResourceScopes:         scopes,
+		ResourceAffixes:        affixes,
+	}, nil
+}
+
+// definitionConstraint converts a definition into a ResourceConstraint. It
+// reports false when the definition carries no rules at all.
+func definitionConstraint(label string, definition ResourceDefinition) (ResourceConstraint, bool) {
+	constraint := ResourceConstraint{
+		MinLen:              definition.MinLength,
+		MaxLen:              definition.MaxLength,
+		PatternDescription:  definition.PatternDescription,
+		ForbiddenPrefixes:   definition.ForbiddenPrefixes,
+		ForbiddenSuffixes:   definition.ForbiddenSuffixes,
+		ForbiddenSubstrings: definition.ForbiddenSubstrings,
+		DisallowIPAddress:   definition.DisallowIPAddress,
+		CaseInsensitive:     definition.CaseInsensitive,
+	}
+	for _, value := range definition.ForbiddenPatterns {
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			continue
+		}
+		constraint.ForbiddenPatterns = append(constraint.ForbiddenPatterns, pattern)
+	}
+
+	regexValue := definitionRegex(definition)
+	if regexValue != "" {
+		if constraint.PatternDescription == "" {
+			constraint.PatternDescription = fmt.Sprintf("must match %s regex %q", label, regexValue)
+		}
+		if pattern, err := regexp.Compile(regexValue); err == nil {
+			constraint.Pattern = pattern
+		}
+	}
+
+	empty := constraint.MinLen == 0 && constraint.MaxLen == 0 && regexValue == "" &&
+		len(constraint.ForbiddenPrefixes) == 0 && len(constraint.ForbiddenSuffixes) == 0 &&
+		len(constraint.ForbiddenSubstrings) == 0 && len(constraint.ForbiddenPatterns) == 0 &&
+		!constraint.DisallowIPAddress
+	return constraint, !empty
+}
+
+// definitionRegex returns the validation regex without the surrounding
+// quotes Azure CAF wraps it in.
+func definitionRegex(definition ResourceDefinition) string {
+	return strings.Trim(strings.TrimSpace(definition.ValidationRegex), `"`)
+}
@@ -0,0 +1,44 @@
+package naming
+
+import "strings"
+
+// Uniqueness scopes reported in BuildResult.Scope. Two resources of the same
+// type must not share a name within the same scope.
+const (
+	ScopeGlobal        = "global"
+	ScopeSubscription  = "subscription"
+	ScopeRegion        = "region"
+	ScopeResourceGroup = "resource_group"
+	ScopeParent        = "parent"
+)
+
+// azureCAFScope maps an Azure CAF scope value to a uniqueness scope.
+func azureCAFScope(scope string) string {
+	switch strings.ToLower(strings.TrimSpace(scope)) {
+	case "global":
+		return ScopeGlobal
+	case "region", "location":
+		return ScopeRegion
+	case "resourcegroup":
+		return ScopeResourceGroup
+	case "parent":
+		return ScopeParent
+	default:
+		return ScopeSubscription
+	}
+}
+
+// resourceScope returns the uniqueness scope for a resource. Explicit
+// entries in scopes win; otherwise regional resources are unique per region
+// and everything else is unique globally.
+func resourceScope(resourceKeys []string, scopes map[string]string, regionalResources map[string]bool) string {
+	for _, resourceKey := range resourceKeys {
+		if scope, ok := scopes[resourceKey]; ok && scope != "" {
+			return scope
+		}
+	}
+	if isRegionalResource(resourceKeys, regionalResources) {
+		return ScopeRegion
+	}
+	return ScopeGlobal
+}
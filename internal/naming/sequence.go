@@ -0,0 +1,106 @@
+package naming
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// SequenceIndexComponent is the recipe item replaced by the sequence index.
+const SequenceIndexComponent = "index"
+
+// Sequence limits. A run is built in memory at once, and no resource name
+// has room for a wider index.
+const (
+	MaxSequenceCount   = 1000
+	MaxSequencePadding = 10
+)
+
+// SequenceInput describes a run of indexed names.
+type SequenceInput struct {
+	Count   int
+	Start   int
+	Padding int
+}
+
+// BuildSequence builds Count names whose "index" component runs from Start,
+// zero-padded to Padding digits. When the recipe has no "index" item it is
+// appended. The widest index is built first so that constraint violations,
+// such as exceeding MaxLen, fail before any other name is produced.
+func BuildSequence(cfg Config, in BuildInput, seq SequenceInput) ([]BuildResult, error) {
+	compiled, err := Compile(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.BuildSequence(in, seq)
+}
+
+// BuildSequence builds a run of indexed names from the compiled
+// configuration. See the BuildSequence function.
+func (c *Compiled) BuildSequence(in BuildInput, seq SequenceInput) ([]BuildResult, error) {
+	if seq.Count < 1 || seq.Count > MaxSequenceCount {
+		return nil, fmt.Errorf("sequence count must be between 1 and %d, got %d", MaxSequenceCount, seq.Count)
+	}
+	if seq.Start < 0 {
+		return nil, fmt.Errorf("sequence start must not be negative, got %d", seq.Start)
+	}
+	if seq.Start > math.MaxInt-seq.Count+1 {
+		return nil, fmt.Errorf("sequence start %d is too large for %d names", seq.Start, seq.Count)
+	}
+	if seq.Padding < 0 || seq.Padding > MaxSequencePadding {
+		return nil, fmt.Errorf("sequence padding must be between 0 and %d, got %d", MaxSequencePadding, seq.Padding)
+	}
+
+	recipe, recipeSource := resolveRecipe(in.Recipe, resourceLookupCandidates(c.cfg.Cloud, in.Resource), c.cfg)
+	if !recipeHasComponent(recipe, SequenceIndexComponent) {
+		recipe = append(append([]string{}, recipe...), SequenceIndexComponent)
+	}
+	in.Recipe = recipe
+
+	build := func(index int) (BuildResult, error) {
+		indexed := in
+		indexed.Overrides = make(map[string]string, len(in.Overrides)+1)
+		for key, val := range in.Overrides {
+			indexed.Overrides[key] = val
+		}
+		indexed.Overrides[SequenceIndexComponent] = formatSequenceIndex(index, seq.Padding)
+		result, err := c.BuildName(indexed)
+		if err != nil {
+			return BuildResult{}, fmt.Errorf("sequence index %s: %w", formatSequenceIndex(index, seq.Padding), err)
+		}
+		result.RecipeSource = recipeSource
+		return result, nil
+	}
+
+	last := seq.Start + seq.Count - 1
+	if _, err := build(last); err != nil {
+		return nil, err
+	}
+
+	results := make([]BuildResult, 0, seq.Count)
+	for offset := 0; offset < seq.Count; offset++ {
+		result, err := build(seq.Start + offset)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func formatSequenceIndex(index, padding int) string {
+	value := strconv.Itoa(index)
+	for len(value) < padding {
+		value = "0" + value
+	}
+	return value
+}
+
+func recipeHasComponent(recipe []string, component string) bool {
+	for _, item := range recipe {
+		if canonicalComponentKey(item) == component {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package naming
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Transliteration fallbacks decide what happens to non-ASCII characters that
+// have no entry in the transliteration table.
+const (
+	// TransliterationStrip drops characters that cannot be transliterated.
+	TransliterationStrip = "strip"
+	// TransliterationError rejects components that contain such characters.
+	TransliterationError = "error"
+)
+
+var transliterationTable = map[rune]string{
+	// German
+	'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss",
+	'Ä': "Ae", 'Ö': "Oe", 'Ü': "Ue", 'ẞ': "SS",
+	// Polish
+	'ą': "a", 'ć': "c", 'ę': "e", 'ł': "l", 'ń': "n", 'ó': "o", 'ś': "s", 'ź': "z", 'ż': "z",
+	'Ą': "A", 'Ć': "C", 'Ę': "E", 'Ł': "L", 'Ń': "N", 'Ó': "O", 'Ś': "S", 'Ź': "Z", 'Ż': "Z",
+	// Czech and Slovak
+	'č': "c", 'ď': "d", 'ě': "e", 'ň': "n", 'ř': "r", 'š': "s", 'ť': "t", 'ů': "u", 'ž': "z", 'ľ': "l", 'ĺ': "l", 'ŕ': "r",
+	'Č': "C", 'Ď': "D", 'Ě': "E", 'Ň': "N", 'Ř': "R", 'Š': "S", 'Ť': "T", 'Ů': "U", 'Ž': "Z", 'Ľ': "L", 'Ĺ': "L", 'Ŕ': "R",
+	// Nordic
+	'å': "aa", 'æ': "ae", 'ø': "oe",
+	'Å': "Aa", 'Æ': "Ae", 'Ø': "Oe",
+	// French, Spanish, Portuguese, Italian
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ô': "o", 'õ': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u",
+	'ý': "y", 'ÿ': "y",
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A",
+	'Ç': "C",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I",
+	'Ñ': "N",
+	'Ò': "O", 'Ô': "O", 'Õ': "O", 'Œ': "Oe",
+	'Ù': "U", 'Ú': "U", 'Û': "U",
+	'Ý': "Y", 'Ÿ': "Y",
+	// Hungarian, Romanian, Turkish
+	'ő': "o", 'ű': "u", 'ă': "a", 'ș': "s", 'ş': "s", 'ț': "t", 'ţ': "t", 'ğ': "g", 'ı': "i",
+	'Ő': "O", 'Ű': "U", 'Ă': "A", 'Ș': "S", 'Ş': "S", 'Ț': "T", 'Ţ': "T", 'Ğ': "G", 'İ': "I",
+}
+
+// IsValidTransliteration reports whether policy is a supported transliteration fallback.
+func IsValidTransliteration(policy string) bool {
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case "", TransliterationStrip, TransliterationError:
+		return true
+	default:
+		return false
+	}
+}
+
+// transliterate replaces non-ASCII characters in value using the
+// transliteration table. Characters without an entry are returned in dropped
+// and removed from the result.
+func transliterate(value string) (string, []rune) {
+	if isASCII(value) {
+		return value, nil
+	}
+	var b strings.Builder
+	dropped := []rune{}
+	for _, r := range value {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+		if replacement, ok := transliterationTable[r]; ok {
+			b.WriteString(replacement)
+			continue
+		}
+		dropped = append(dropped, r)
+	}
+	return b.String(), dropped
+}
+
+// transliterateComponents rewrites non-ASCII component values in place and
+// returns a warning for every value that changed.
+func transliterateComponents(components map[string]string, policy string) ([]string, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if !IsValidTransliteration(policy) {
+		return nil, fmt.Errorf("unsupported transliteration %q; valid values are %q and %q", policy, TransliterationStrip, TransliterationError)
+	}
+
+	keys := make([]string, 0, len(components))
+	for key := range components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	warnings := []string{}
+	for _, key := range keys {
+		original := components[key]
+		converted, dropped := transliterate(original)
+		if converted == original {
+			continue
+		}
+		if len(dropped) > 0 && policy == TransliterationError {
+			return nil, &ComponentError{Component: key, Value: original, Reason: fmt.Sprintf("contains characters that cannot be transliterated: %q", string(dropped))}
+		}
+		converted = strings.TrimSpace(converted)
+		components[key] = converted
+		if len(dropped) > 0 {
+			warnings = append(warnings, fmt.Sprintf("component %q value %q was changed to %q; dropped characters %q", key, original, converted, string(dropped)))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("component %q value %q was transliterated to %q", key, original, converted))
+		}
+	}
+	return warnings, nil
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
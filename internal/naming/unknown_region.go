@@ -0,0 +1,99 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownRegionError reports a region that is not in the region map.
+// Suggestion is the closest known region, or empty when none is close.
+type UnknownRegionError struct {
+	Region     string
+	Suggestion string
+}
+
+func (e *UnknownRegionError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("unknown region %q; did you mean %q?", e.Region, e.Suggestion)
+	}
+	return fmt.Sprintf("unknown region %q; it is not in the region map", e.Region)
+}
+
+// UnknownRegions returns the configured regions missing from the region map.
+// They are used as-is unless Config.StrictRegions is set, in which case
+// Compile fails instead.
+func (c *Compiled) UnknownRegions() []UnknownRegionError {
+	var out []UnknownRegionError
+	for _, unknown := range c.unknownRegions(c.cfg.Regions) {
+		out = append(out, *unknown)
+	}
+	return out
+}
+
+// unknownRegions returns an error for each of regions, or Config.Region when
+// regions is empty, that the region map does not know. Nothing is checked
+// when Config.RegionShortCode is set, since the regions are not looked up.
+func (c *Compiled) unknownRegions(regions []string) []*UnknownRegionError {
+	if strings.TrimSpace(c.cfg.RegionShortCode) != "" {
+		return nil
+	}
+	checked := make([]string, 0, len(regions))
+	for _, region := range regions {
+		if region = strings.TrimSpace(region); region != "" {
+			checked = append(checked, region)
+		}
+	}
+	if len(checked) == 0 {
+		if region := strings.TrimSpace(c.cfg.Region); region != "" {
+			checked = append(checked, region)
+		}
+	}
+
+	var out []*UnknownRegionError
+	for _, region := range checked {
+		if c.regions.lookup(region) == "" {
+			out = append(out, &UnknownRegionError{Region: region, Suggestion: c.regions.suggest(region)})
+		}
+	}
+	return out
+}
+
+// suggest returns the known region closest to region by edit distance
+// between normalized keys, or "" when none is within a third of its length.
+// Ties go to the alphabetically first region.
+func (x regionIndex) suggest(region string) string {
+	target := normalizeRegionKey(region)
+	if target == "" {
+		return ""
+	}
+	best, bestDistance := "", len(target)/3+1
+	for normalized, key := range x.keys {
+		distance := editDistance(target, normalized)
+		if distance < bestDistance || (distance == bestDistance && best != "" && key < best) {
+			best, bestDistance = key, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
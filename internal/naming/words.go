@@ -0,0 +1,95 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Word split modes control how component values are broken into words
+// before a style is applied.
+const (
+	// WordSplitAlnum splits on anything that is not a letter or digit. This
+	// is the default and keeps paymentWorker as a single word.
+	WordSplitAlnum = "alnum"
+	// WordSplitCase additionally splits on case transitions, keeping runs of
+	// capitals together as acronyms (HTTPServer -> HTTP, Server).
+	WordSplitCase = "case"
+	// WordSplitCaseDigits additionally splits between letters and digits
+	// (node2Pool -> node, 2, Pool).
+	WordSplitCaseDigits = "case_digits"
+)
+
+type wordSplitter struct {
+	mode string
+}
+
+// newWordSplitter returns the splitter for mode. An empty mode selects WordSplitAlnum.
+func newWordSplitter(mode string) (wordSplitter, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(mode)); normalized {
+	case "":
+		return wordSplitter{mode: WordSplitAlnum}, nil
+	case WordSplitAlnum, WordSplitCase, WordSplitCaseDigits:
+		return wordSplitter{mode: normalized}, nil
+	default:
+		return wordSplitter{}, fmt.Errorf("unsupported word_split %q; valid values are %q, %q, and %q", mode, WordSplitAlnum, WordSplitCase, WordSplitCaseDigits)
+	}
+}
+
+// IsValidWordSplit reports whether mode is a supported word split mode.
+func IsValidWordSplit(mode string) bool {
+	_, err := newWordSplitter(mode)
+	return err == nil
+}
+
+func (s wordSplitter) words(value string) []string {
+	switch s.mode {
+	case WordSplitCase:
+		return splitCaseWords(value, false)
+	case WordSplitCaseDigits:
+		return splitCaseWords(value, true)
+	default:
+		return splitWords(value)
+	}
+}
+
+// caseAware reports whether words inside a single component keep their own
+// boundaries in camel style. The legacy alnum mode joins them instead.
+func (s wordSplitter) caseAware() bool {
+	return s.mode == WordSplitCase || s.mode == WordSplitCaseDigits
+}
+
+func splitCaseWords(value string, digits bool) []string {
+	words := []string{}
+	for _, run := range splitWords(value) {
+		runes := []rune(run)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			if isWordBoundary(runes, i, digits) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+func isWordBoundary(runes []rune, i int, digits bool) bool {
+	prev, cur := runes[i-1], runes[i]
+	if digits && unicode.IsDigit(prev) != unicode.IsDigit(cur) {
+		return true
+	}
+	if unicode.IsLower(prev) && unicode.IsUpper(cur) {
+		return true
+	}
+	// The last capital of an acronym starts the next word: HTTPServer -> HTTP, Server.
+	if unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+		return true
+	}
+	// Without digit splitting, a digit followed by a capital still starts a word: v2Api -> v2, Api.
+	if !digits && unicode.IsDigit(prev) && unicode.IsUpper(cur) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultZonalResources lists AWS resources that live in a single availability zone.
+func DefaultZonalResources() map[string]bool {
+	return map[string]bool{
+		"subnet":       true,
+		"ebs":          true,
+		"ec2_instance": true,
+		"nat_gw":       true,
+	}
+}
+
+// DefaultGCPZonalResources lists GCP resources that live in a single zone.
+func DefaultGCPZonalResources() map[string]bool {
+	return map[string]bool{
+		"compute_instance":               true,
+		"compute_disk":                   true,
+		"compute_instance_group_manager": true,
+	}
+}
+
+// DefaultAzureZonalResources lists Azure resources commonly pinned to one availability zone.
+func DefaultAzureZonalResources() map[string]bool {
+	return map[string]bool{
+		"azurerm_linux_virtual_machine":   true,
+		"azurerm_windows_virtual_machine": true,
+		"azurerm_virtual_machine":         true,
+		"azurerm_managed_disk":            true,
+	}
+}
+
+// zoneShortCode derives a short zone code from a zone name. Zones that start
+// with a known region reuse the region short code followed by the zone
+// suffix (us-east-1a -> use1a, europe-west1-b -> euw1b). Azure zone numbers
+// become z<n>. Anything else falls back to the lowercase alphanumeric zone.
+func zoneShortCode(cloud, zone string, regions regionIndex) string {
+	zone = strings.TrimSpace(zone)
+	if zone == "" {
+		return ""
+	}
+
+	if NormalizeCloud(cloud) == CloudAzure && isDigits(zone) {
+		return "z" + zone
+	}
+
+	for i := len(zone) - 1; i > 0; i-- {
+		prefix := strings.TrimRight(zone[:i], "-_ ")
+		if prefix == "" {
+			continue
+		}
+		if code := regions.lookup(prefix); code != "" {
+			return code + toLowerAlnum(zone[i:])
+		}
+	}
+
+	return toLowerAlnum(zone)
+}
+
+func isZonalResource(resourceKeys []string, zonalResources map[string]bool) bool {
+	for _, resourceKey := range resourceKeys {
+		if zonalResources[resourceKey] {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
+)
+
+type CatalogDataSource struct {
+	providerData *ProviderData
+}
+
+type catalogDataSourceModel struct {
+	Prefix    types.String `tfsdk:"prefix"`
+	Tags      types.List   `tfsdk:"tags"`
+	Cloud     types.String `tfsdk:"cloud"`
+	Keys      types.List   `tfsdk:"keys"`
+	Resources types.Map    `tfsdk:"resources"`
+}
+
+type catalogEntryModel struct {
+	Acronym            types.String `tfsdk:"acronym"`
+	Styles             types.List   `tfsdk:"styles"`
+	Regional           types.Bool   `tfsdk:"regional"`
+	Zonal              types.Bool   `tfsdk:"zonal"`
+	Scope              types.String `tfsdk:"scope"`
+	MinLength          types.Int64  `tfsdk:"min_length"`
+	MaxLength          types.Int64  `tfsdk:"max_length"`
+	PatternDescription types.String `tfsdk:"pattern_description"`
+	Tags               types.List   `tfsdk:"tags"`
+}
+
+var catalogEntryAttrTypes = map[string]attr.Type{
+	"acronym":             types.StringType,
+	"styles":              types.ListType{ElemType: types.StringType},
+	"regional":            types.BoolType,
+	"zonal":               types.BoolType,
+	"scope":               types.StringType,
+	"min_length":          types.Int64Type,
+	"max_length":          types.Int64Type,
+	"pattern_description": types.StringType,
+	"tags":                types.ListType{ElemType: types.StringType},
+}
+
+func NewCatalogDataSource() datasource.DataSource {
+	return &CatalogDataSource{}
+}
+
+func (d *CatalogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalog"
+}
+
+func (d *CatalogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"prefix": schema.StringAttribute{
+				Optional: true,
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"cloud": schema.StringAttribute{
+				Computed: true,
+			},
+			"keys": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"resources": schema.MapNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"acronym": schema.StringAttribute{
+							Computed: true,
+						},
+						"styles": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"regional": schema.BoolAttribute{
+							Computed: true,
+						},
+						"zonal": schema.BoolAttribute{
+							Computed: true,
+						},
+						"scope": schema.StringAttribute{
+							Computed: true,
+						},
+						"min_length": schema.Int64Attribute{
+							Computed: true,
+						},
+						"max_length": schema.Int64Attribute{
+							Computed: true,
+						},
+						"pattern_description": schema.StringAttribute{
+							Computed: true,
+						},
+						"tags": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CatalogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *CatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider has not been configured yet.")
+		return
+	}
+
+	var data catalogDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags := decodeStringList(ctx, data.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := d.providerData.namer.Catalog(sigil.CatalogFilter{
+		Prefix: data.Prefix.ValueString(),
+		Tags:   tags,
+	})
+
+	keys := make([]string, 0, len(entries))
+	resources := make(map[string]catalogEntryModel, len(entries))
+	for _, entry := range entries {
+		styles, diags := types.ListValueFrom(ctx, types.StringType, entry.Styles)
+		resp.Diagnostics.Append(diags...)
+		entryTags, diags := types.ListValueFrom(ctx, types.StringType, entry.Tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		keys = append(keys, entry.Resource)
+		resources[entry.Resource] = catalogEntryModel{
+			Acronym:            types.StringValue(entry.Acronym),
+			Styles:             styles,
+			Regional:           types.BoolValue(entry.Regional),
+			Zonal:              types.BoolValue(entry.Zonal),
+			Scope:              types.StringValue(entry.Scope),
+			MinLength:          types.Int64Value(int64(entry.MinLen)),
+			MaxLength:          types.Int64Value(int64(entry.MaxLen)),
+			PatternDescription: types.StringValue(entry.PatternDescription),
+			Tags:               entryTags,
+		}
+	}
+
+	data.Cloud = types.StringValue(naming.NormalizeCloud(d.providerData.Cloud))
+
+	keysValue, diags := types.ListValueFrom(ctx, types.StringType, keys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Keys = keysValue
+
+	resourcesValue, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: catalogEntryAttrTypes}, resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Resources = resourcesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
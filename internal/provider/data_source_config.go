@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+// configSettings lists the provider settings whose source layer is reported.
+var configSettings = []string{
+	"cloud",
+	"org_prefix",
+	"project",
+	"env",
+	"env_map",
+	"allowed_envs",
+	"region",
+	"regions",
+	"strict_regions",
+	"region_short_code",
+	"region_map",
+	"zone",
+	"recipe",
+	"style_priority",
+	"word_split",
+	"transliteration",
+	"duplicate_detection",
+	"ignore_region_for_regional_resources",
+	"resource_acronyms",
+	"resource_style_overrides",
+	"resource_recipes",
+	"resource_aliases",
+	"acronym_collision_policy",
+	"display_recipe",
+	"display_style",
+	"display_expansions",
+	"component_rules",
+	"enforcement",
+	"enforcement_overrides",
+	"denied_words",
+	"denied_patterns",
+	"deny_reserved_words",
+	"hash_inputs",
+	"hash_algorithm",
+	"hash_encoding",
+	"hash_length",
+	"account",
+	"subscription",
+	"gcp_project",
+	"account_map",
+}
+
+type ConfigDataSource struct {
+	providerData *ProviderData
+}
+
+type configDataSourceModel struct {
+	Cloud                            types.String `tfsdk:"cloud"`
+	OrgPrefix                        types.String `tfsdk:"org_prefix"`
+	Project                          types.String `tfsdk:"project"`
+	Env                              types.String `tfsdk:"env"`
+	EnvCode                          types.String `tfsdk:"env_code"`
+	EnvMap                           types.Map    `tfsdk:"env_map"`
+	AllowedEnvs                      types.List   `tfsdk:"allowed_envs"`
+	Region                           types.String `tfsdk:"region"`
+	Regions                          types.List   `tfsdk:"regions"`
+	StrictRegions                    types.Bool   `tfsdk:"strict_regions"`
+	RegionCode                       types.String `tfsdk:"region_code"`
+	RegionMap                        types.Map    `tfsdk:"region_map"`
+	Zone                             types.String `tfsdk:"zone"`
+	Recipe                           types.List   `tfsdk:"recipe"`
+	StylePriority                    types.List   `tfsdk:"style_priority"`
+	WordSplit                        types.String `tfsdk:"word_split"`
+	Transliteration                  types.String `tfsdk:"transliteration"`
+	DuplicateDetection               types.Bool   `tfsdk:"duplicate_detection"`
+	IgnoreRegionForRegionalResources types.Bool   `tfsdk:"ignore_region_for_regional_resources"`
+	ResourceAcronyms                 types.Map    `tfsdk:"resource_acronyms"`
+	ResourceStyleOverrides           types.Map    `tfsdk:"resource_style_overrides"`
+	ResourceRecipes                  types.Map    `tfsdk:"resource_recipes"`
+	ResourceAliases                  types.Map    `tfsdk:"resource_aliases"`
+	AcronymCollisionPolicy           types.String `tfsdk:"acronym_collision_policy"`
+	DisplayRecipe                    types.List   `tfsdk:"display_recipe"`
+	DisplayStyle                     types.String `tfsdk:"display_style"`
+	DisplayExpansions                types.Map    `tfsdk:"display_expansions"`
+	Enforcement                      types.String `tfsdk:"enforcement"`
+	EnforcementOverrides             types.Map    `tfsdk:"enforcement_overrides"`
+	DeniedWords                      types.List   `tfsdk:"denied_words"`
+	DeniedPatterns                   types.List   `tfsdk:"denied_patterns"`
+	DenyReservedWords                types.Bool   `tfsdk:"deny_reserved_words"`
+	HashInputs                       types.List   `tfsdk:"hash_inputs"`
+	HashAlgorithm                    types.String `tfsdk:"hash_algorithm"`
+	HashEncoding                     types.String `tfsdk:"hash_encoding"`
+	HashLength                       types.Int64  `tfsdk:"hash_length"`
+	Account                          types.String `tfsdk:"account"`
+	AccountCode                      types.String `tfsdk:"account_code"`
+	Subscription                     types.String `tfsdk:"subscription"`
+	GCPProject                       types.String `tfsdk:"gcp_project"`
+	AccountMap                       types.Map    `tfsdk:"account_map"`
+	Sources                          types.Map    `tfsdk:"sources"`
+}
+
+func NewConfigDataSource() datasource.DataSource {
+	return &ConfigDataSource{}
+}
+
+func (d *ConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config"
+}
+
+func (d *ConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	stringList := func() schema.Attribute {
+		return schema.ListAttribute{Computed: true, ElementType: types.StringType}
+	}
+	stringMap := func() schema.Attribute {
+		return schema.MapAttribute{Computed: true, ElementType: types.StringType}
+	}
+	listMap := func() schema.Attribute {
+		return schema.MapAttribute{Computed: true, ElementType: types.ListType{ElemType: types.StringType}}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cloud":                                schema.StringAttribute{Computed: true},
+			"org_prefix":                           schema.StringAttribute{Computed: true},
+			"project":                              schema.StringAttribute{Computed: true},
+			"env":                                  schema.StringAttribute{Computed: true},
+			"env_code":                             schema.StringAttribute{Computed: true},
+			"env_map":                              stringMap(),
+			"allowed_envs":                         stringList(),
+			"region":                               schema.StringAttribute{Computed: true},
+			"regions":                              stringList(),
+			"strict_regions":                       schema.BoolAttribute{Computed: true},
+			"region_code":                          schema.StringAttribute{Computed: true},
+			"region_map":                           stringMap(),
+			"zone":                                 schema.StringAttribute{Computed: true},
+			"recipe":                               stringList(),
+			"style_priority":                       stringList(),
+			"word_split":                           schema.StringAttribute{Computed: true},
+			"transliteration":                      schema.StringAttribute{Computed: true},
+			"duplicate_detection":                  schema.BoolAttribute{Computed: true},
+			"ignore_region_for_regional_resources": schema.BoolAttribute{Computed: true},
+			"resource_acronyms":                    stringMap(),
+			"resource_style_overrides":             listMap(),
+			"resource_recipes":                     listMap(),
+			"resource_aliases":                     stringMap(),
+			"acronym_collision_policy":             schema.StringAttribute{Computed: true},
+			"display_recipe":                       stringList(),
+			"display_style":                        schema.StringAttribute{Computed: true},
+			"display_expansions":                   stringMap(),
+			"enforcement":                          schema.StringAttribute{Computed: true},
+			"enforcement_overrides":                stringMap(),
+			"denied_words":                         stringList(),
+			"denied_patterns":                      stringList(),
+			"deny_reserved_words":                  schema.BoolAttribute{Computed: true},
+			"hash_inputs":                          stringList(),
+			"hash_algorithm":                       schema.StringAttribute{Computed: true},
+			"hash_encoding":                        schema.StringAttribute{Computed: true},
+			"hash_length":                          schema.Int64Attribute{Computed: true},
+			"account":                              schema.StringAttribute{Computed: true},
+			"account_code":                         schema.StringAttribute{Computed: true},
+			"subscription":                         schema.StringAttribute{Computed: true},
+			"gcp_project":                          schema.StringAttribute{Computed: true},
+			"account_map":                          stringMap(),
+			"sources":                              listMap(),
+		},
+	}
+}
+
+func (d *ConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider has not been configured yet.")
+		return
+	}
+
+	p := d.providerData
+	recipe := p.Recipe
+	if len(recipe) == 0 {
+		recipe = naming.DefaultRecipe()
+	}
+	wordSplit := strings.ToLower(strings.TrimSpace(p.WordSplit))
+	if wordSplit == "" {
+		wordSplit = naming.WordSplitAlnum
+	}
+	transliteration := strings.ToLower(strings.TrimSpace(p.Transliteration))
+	if transliteration == "" {
+		transliteration = naming.TransliterationStrip
+	}
+
+	deniedPatterns := make([]string, 0, len(p.DeniedPatterns))
+	for _, pattern := range p.DeniedPatterns {
+		deniedPatterns = append(deniedPatterns, pattern.String())
+	}
+
+	sources := make(map[string][]string, len(configSettings))
+	for _, setting := range configSettings {
+		sources[setting] = p.source(setting)
+	}
+
+	diags := &resp.Diagnostics
+	data := configDataSourceModel{
+		Cloud:                            types.StringValue(naming.NormalizeCloud(p.Cloud)),
+		OrgPrefix:                        types.StringValue(p.OrgPrefix),
+		Project:                          types.StringValue(p.Project),
+		Env:                              types.StringValue(p.Env),
+		EnvCode:                          types.StringValue(naming.EnvCode(p.EnvMap, p.Env)),
+		EnvMap:                           stringMapValue(ctx, p.EnvMap, diags),
+		AllowedEnvs:                      stringListValue(ctx, p.AllowedEnvs, diags),
+		Region:                           types.StringValue(p.Region),
+		Regions:                          stringListValue(ctx, p.Regions, diags),
+		StrictRegions:                    types.BoolValue(p.StrictRegions),
+		RegionCode:                       types.StringValue(p.namer.RegionCode()),
+		RegionMap:                        stringMapValue(ctx, p.RegionMap, diags),
+		Zone:                             types.StringValue(p.Zone),
+		Recipe:                           stringListValue(ctx, recipe, diags),
+		StylePriority:                    stringListValue(ctx, p.StylePriority, diags),
+		WordSplit:                        types.StringValue(wordSplit),
+		Transliteration:                  types.StringValue(transliteration),
+		DuplicateDetection:               types.BoolValue(p.DuplicateDetection),
+		IgnoreRegionForRegionalResources: types.BoolValue(p.IgnoreRegionForRegionalResources),
+		ResourceAcronyms:                 stringMapValue(ctx, p.ResourceAcronyms, diags),
+		ResourceStyleOverrides:           listMapValue(ctx, p.ResourceStyleOverrides, diags),
+		ResourceRecipes:                  listMapValue(ctx, p.ResourceRecipes, diags),
+		ResourceAliases:                  stringMapValue(ctx, p.ResourceAliases, diags),
+		AcronymCollisionPolicy:           types.StringValue(p.AcronymCollisionPolicy),
+		DisplayRecipe:                    stringListValue(ctx, p.DisplayRecipe, diags),
+		DisplayStyle:                     types.StringValue(p.DisplayStyle),
+		DisplayExpansions:                stringMapValue(ctx, p.DisplayExpansions, diags),
+		Enforcement:                      types.StringValue(p.Enforcement),
+		EnforcementOverrides:             stringMapValue(ctx, p.EnforcementOverrides, diags),
+		DeniedWords:                      stringListValue(ctx, p.DeniedWords, diags),
+		DeniedPatterns:                   stringListValue(ctx, deniedPatterns, diags),
+		DenyReservedWords:                types.BoolValue(p.DenyReservedWords),
+		HashInputs:                       stringListValue(ctx, p.HashInputs, diags),
+		HashAlgorithm:                    types.StringValue(p.HashAlgorithm),
+		HashEncoding:                     types.StringValue(p.HashEncoding),
+		HashLength:                       types.Int64Value(int64(p.HashLength)),
+		Account:                          types.StringValue(p.Account),
+		AccountCode:                      types.StringValue(naming.AccountCode(p.AccountMap, p.Account)),
+		Subscription:                     types.StringValue(p.Subscription),
+		GCPProject:                       types.StringValue(p.GCPProject),
+		AccountMap:                       stringMapValue(ctx, p.AccountMap, diags),
+		Sources:                          listMapValue(ctx, sources, diags),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func stringMapValue(ctx context.Context, values map[string]string, diags *diag.Diagnostics) types.Map {
+	if values == nil {
+		values = map[string]string{}
+	}
+	value, d := types.MapValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return value
+}
+
+func stringListValue(ctx context.Context, values []string, diags *diag.Diagnostics) types.List {
+	if values == nil {
+		values = []string{}
+	}
+	value, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return value
+}
+
+func listMapValue(ctx context.Context, values map[string][]string, diags *diag.Diagnostics) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for key, list := range values {
+		elements[key] = stringListValue(ctx, list, diags)
+	}
+	value, d := types.MapValue(types.ListType{ElemType: types.StringType}, elements)
+	diags.Append(d...)
+	return value
+}
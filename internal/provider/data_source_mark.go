@@ -2,12 +2,12 @@ package provider
 
 import (
 	"context"
-	"strings"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
 )
 
 type MarkDataSource struct {
@@ -18,13 +18,25 @@ type markDataSourceModel struct {
 	Resource        types.String `tfsdk:"resource"`
 	What            types.String `tfsdk:"what"`
 	Qualifier       types.String `tfsdk:"qualifier"`
+	Zone            types.String `tfsdk:"zone"`
+	Regions         types.List   `tfsdk:"regions"`
 	Overrides       types.Map    `tfsdk:"overrides"`
 	Recipe          types.List   `tfsdk:"recipe"`
 	StylePriority   types.List   `tfsdk:"style_priority"`
+	DisplayRecipe   types.List   `tfsdk:"display_recipe"`
+	DisplayStyle    types.String `tfsdk:"display_style"`
+	ScopeKey        types.String `tfsdk:"scope_key"`
+	Parent          types.Map    `tfsdk:"parent"`
+	ParentMode      types.String `tfsdk:"parent_mode"`
+	Fifo            types.Bool   `tfsdk:"fifo"`
 	Name            types.String `tfsdk:"name"`
+	DisplayName     types.String `tfsdk:"display_name"`
 	Style           types.String `tfsdk:"style"`
 	RegionCode      types.String `tfsdk:"region_code"`
+	ZoneCode        types.String `tfsdk:"zone_code"`
 	ResourceAcronym types.String `tfsdk:"resource_acronym"`
+	RecipeSource    types.String `tfsdk:"recipe_source"`
+	Scope           types.String `tfsdk:"scope"`
 	Components      types.Map    `tfsdk:"components"`
 	Parts           types.List   `tfsdk:"parts"`
 }
@@ -50,6 +62,13 @@ func (d *MarkDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 			"qualifier": schema.StringAttribute{
 				Optional: true,
 			},
+			"zone": schema.StringAttribute{
+				Optional: true,
+			},
+			"regions": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"overrides": schema.MapAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
@@ -62,18 +81,50 @@ func (d *MarkDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"display_recipe": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"display_style": schema.StringAttribute{
+				Optional: true,
+			},
+			"scope_key": schema.StringAttribute{
+				Optional: true,
+			},
+			"parent": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"parent_mode": schema.StringAttribute{
+				Optional: true,
+			},
+			"fifo": schema.BoolAttribute{
+				Optional: true,
+			},
 			"name": schema.StringAttribute{
 				Computed: true,
 			},
+			"display_name": schema.StringAttribute{
+				Computed: true,
+			},
 			"style": schema.StringAttribute{
 				Computed: true,
 			},
 			"region_code": schema.StringAttribute{
 				Computed: true,
 			},
+			"zone_code": schema.StringAttribute{
+				Computed: true,
+			},
 			"resource_acronym": schema.StringAttribute{
 				Computed: true,
 			},
+			"recipe_source": schema.StringAttribute{
+				Computed: true,
+			},
+			"scope": schema.StringAttribute{
+				Computed: true,
+			},
 			"components": schema.MapAttribute{
 				Computed:    true,
 				ElementType: types.StringType,
@@ -111,75 +162,61 @@ func (d *MarkDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	overrides := map[string]string{}
-	if !data.Overrides.IsNull() && !data.Overrides.IsUnknown() {
-		resp.Diagnostics.Append(data.Overrides.ElementsAs(ctx, &overrides, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	}
-
-	recipe := []string{}
-	if !data.Recipe.IsNull() && !data.Recipe.IsUnknown() {
-		resp.Diagnostics.Append(data.Recipe.ElementsAs(ctx, &recipe, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	}
-
-	stylePriority := []string{}
-	if !data.StylePriority.IsNull() && !data.StylePriority.IsUnknown() {
-		resp.Diagnostics.Append(data.StylePriority.ElementsAs(ctx, &stylePriority, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	}
-
-	what := strings.TrimSpace(data.What.ValueString())
-	resource := strings.TrimSpace(data.Resource.ValueString())
-	if what == "" && resource == "" {
-		resp.Diagnostics.AddError("Missing required attribute", "Either `what` (preferred) or `resource` must be set.")
+	overrides := decodeStringMap(ctx, data.Overrides, &resp.Diagnostics)
+	parent := decodeStringMap(ctx, data.Parent, &resp.Diagnostics)
+	recipe := decodeStringList(ctx, data.Recipe, &resp.Diagnostics)
+	stylePriority := decodeStringList(ctx, data.StylePriority, &resp.Diagnostics)
+	regions := decodeStringList(ctx, data.Regions, &resp.Diagnostics)
+	displayRecipe := decodeStringList(ctx, data.DisplayRecipe, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	if what != "" && resource != "" && what != resource {
-		resp.Diagnostics.AddError("Conflicting attributes", "`what` and `resource` cannot both be set to different values.")
+
+	what := resolveWhat(data.What, data.Resource, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	if what == "" {
-		what = resource
-	}
 
-	result, err := naming.BuildName(naming.Config{
-		Cloud:                            d.providerData.Cloud,
-		OrgPrefix:                        d.providerData.OrgPrefix,
-		Project:                          d.providerData.Project,
-		Env:                              d.providerData.Env,
-		Region:                           d.providerData.Region,
-		RegionShortCode:                  d.providerData.RegionShortCode,
-		RegionMap:                        d.providerData.RegionMap,
-		Recipe:                           d.providerData.Recipe,
-		StylePriority:                    d.providerData.StylePriority,
-		ResourceAcronyms:                 d.providerData.ResourceAcronyms,
-		ResourceStyleOverrides:           d.providerData.ResourceStyleOverrides,
-		ResourceConstraints:              d.providerData.ResourceConstraints,
-		IgnoreRegionForRegionalResources: d.providerData.IgnoreRegionForRegionalResources,
-		RegionalResources:                d.providerData.RegionalResources,
-	}, naming.BuildInput{
+	result, err := d.providerData.namer.Name(sigil.Input{
 		Resource:      what,
 		Qualifier:     data.Qualifier.ValueString(),
+		Zone:          data.Zone.ValueString(),
+		Regions:       regions,
 		Overrides:     overrides,
 		Recipe:        recipe,
 		StylePriority: stylePriority,
+		DisplayRecipe: displayRecipe,
+		DisplayStyle:  data.DisplayStyle.ValueString(),
+		ScopeKey:      data.ScopeKey.ValueString(),
+		Parent:        parent,
+		ParentMode:    data.ParentMode.ValueString(),
+		Fifo:          data.Fifo.ValueBool(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Name build failed", err.Error())
+		addBuildError(&resp.Diagnostics, err)
 		return
 	}
 
+	description := fmt.Sprintf("what = %q, qualifier = %q", what, data.Qualifier.ValueString())
+	if previous, ok := d.providerData.names.register(d.providerData.registryKey(what), result, description); !ok {
+		resp.Diagnostics.AddError("Duplicate name", duplicateNameDetail(what, result, previous))
+		return
+	}
+
+	for _, warning := range result.Warnings {
+		resp.Diagnostics.AddWarning("Component value altered", warning)
+	}
+	addConstraintWarnings(&resp.Diagnostics, result.Violations)
+	addUnknownRegionWarnings(&resp.Diagnostics, result.UnknownRegions)
+
 	data.Name = types.StringValue(result.Name)
+	data.DisplayName = types.StringValue(result.DisplayName)
 	data.Style = types.StringValue(result.Style)
 	data.RegionCode = types.StringValue(result.RegionCode)
+	data.ZoneCode = types.StringValue(result.ZoneCode)
 	data.ResourceAcronym = types.StringValue(result.ResourceAcronym)
+	data.RecipeSource = types.StringValue(result.RecipeSource)
+	data.Scope = types.StringValue(result.Scope)
 
 	componentsValue, diags := types.MapValueFrom(ctx, types.StringType, result.Components)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
+)
+
+const (
+	defaultSequenceStart   = 1
+	defaultSequencePadding = 2
+)
+
+type MarkSequenceDataSource struct {
+	providerData *ProviderData
+}
+
+type markSequenceDataSourceModel struct {
+	What            types.String `tfsdk:"what"`
+	Qualifier       types.String `tfsdk:"qualifier"`
+	Zone            types.String `tfsdk:"zone"`
+	Regions         types.List   `tfsdk:"regions"`
+	Overrides       types.Map    `tfsdk:"overrides"`
+	Recipe          types.List   `tfsdk:"recipe"`
+	StylePriority   types.List   `tfsdk:"style_priority"`
+	ScopeKey        types.String `tfsdk:"scope_key"`
+	Parent          types.Map    `tfsdk:"parent"`
+	ParentMode      types.String `tfsdk:"parent_mode"`
+	Fifo            types.Bool   `tfsdk:"fifo"`
+	Size            types.Int64  `tfsdk:"size"`
+	Start           types.Int64  `tfsdk:"start"`
+	Padding         types.Int64  `tfsdk:"padding"`
+	Names           types.List   `tfsdk:"names"`
+	Style           types.String `tfsdk:"style"`
+	RegionCode      types.String `tfsdk:"region_code"`
+	ResourceAcronym types.String `tfsdk:"resource_acronym"`
+	RecipeSource    types.String `tfsdk:"recipe_source"`
+	Scope           types.String `tfsdk:"scope"`
+}
+
+func NewMarkSequenceDataSource() datasource.DataSource {
+	return &MarkSequenceDataSource{}
+}
+
+func (d *MarkSequenceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mark_sequence"
+}
+
+func (d *MarkSequenceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"what": schema.StringAttribute{
+				Required: true,
+			},
+			"qualifier": schema.StringAttribute{
+				Optional: true,
+			},
+			"zone": schema.StringAttribute{
+				Optional: true,
+			},
+			"regions": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"overrides": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"recipe": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"style_priority": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"scope_key": schema.StringAttribute{
+				Optional: true,
+			},
+			"parent": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"parent_mode": schema.StringAttribute{
+				Optional: true,
+			},
+			"fifo": schema.BoolAttribute{
+				Optional: true,
+			},
+			"size": schema.Int64Attribute{
+				Required:   true,
+				Validators: []validator.Int64{int64Between{min: 1, max: naming.MaxSequenceCount}},
+			},
+			"start": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64Between{min: 0, max: math.MaxInt32}},
+			},
+			"padding": schema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64Between{min: 0, max: naming.MaxSequencePadding}},
+			},
+			"names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"style": schema.StringAttribute{
+				Computed: true,
+			},
+			"region_code": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_acronym": schema.StringAttribute{
+				Computed: true,
+			},
+			"recipe_source": schema.StringAttribute{
+				Computed: true,
+			},
+			"scope": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *MarkSequenceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *MarkSequenceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider has not been configured yet.")
+		return
+	}
+
+	var data markSequenceDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	overrides := decodeStringMap(ctx, data.Overrides, &resp.Diagnostics)
+	parent := decodeStringMap(ctx, data.Parent, &resp.Diagnostics)
+	recipe := decodeStringList(ctx, data.Recipe, &resp.Diagnostics)
+	stylePriority := decodeStringList(ctx, data.StylePriority, &resp.Diagnostics)
+	regions := decodeStringList(ctx, data.Regions, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	what := resolveWhat(data.What, types.StringNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seq := naming.SequenceInput{
+		Count:   int(data.Size.ValueInt64()),
+		Start:   defaultSequenceStart,
+		Padding: defaultSequencePadding,
+	}
+	if !data.Start.IsNull() && !data.Start.IsUnknown() {
+		seq.Start = int(data.Start.ValueInt64())
+	}
+	if !data.Padding.IsNull() && !data.Padding.IsUnknown() {
+		seq.Padding = int(data.Padding.ValueInt64())
+	}
+
+	results, err := d.providerData.namer.Sequence(sigil.Input{
+		Resource:      what,
+		Qualifier:     data.Qualifier.ValueString(),
+		Zone:          data.Zone.ValueString(),
+		Regions:       regions,
+		Overrides:     overrides,
+		Recipe:        recipe,
+		StylePriority: stylePriority,
+		ScopeKey:      data.ScopeKey.ValueString(),
+		Parent:        parent,
+		ParentMode:    data.ParentMode.ValueString(),
+		Fifo:          data.Fifo.ValueBool(),
+	}, seq)
+	if err != nil {
+		addBuildError(&resp.Diagnostics, err)
+		return
+	}
+
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		description := fmt.Sprintf("what = %q, qualifier = %q (sequence)", what, data.Qualifier.ValueString())
+		if previous, ok := d.providerData.names.register(d.providerData.registryKey(what), result, description); !ok {
+			resp.Diagnostics.AddError("Duplicate name", duplicateNameDetail(what, result, previous))
+			return
+		}
+		names = append(names, result.Name)
+		addConstraintWarnings(&resp.Diagnostics, result.Violations)
+	}
+	for _, warning := range results[0].Warnings {
+		resp.Diagnostics.AddWarning("Component value altered", warning)
+	}
+	addUnknownRegionWarnings(&resp.Diagnostics, results[0].UnknownRegions)
+
+	first := results[0]
+	data.Style = types.StringValue(first.Style)
+	data.RegionCode = types.StringValue(first.RegionCode)
+	data.ResourceAcronym = types.StringValue(first.ResourceAcronym)
+	data.RecipeSource = types.StringValue(first.RecipeSource)
+	data.Scope = types.StringValue(first.Scope)
+
+	namesValue, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Names = namesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+// decodeStringMap returns the elements of a string map attribute, or an empty
+// map when the attribute is null or unknown.
+func decodeStringMap(ctx context.Context, value types.Map, diags *diag.Diagnostics) map[string]string {
+	out := map[string]string{}
+	if value.IsNull() || value.IsUnknown() {
+		return out
+	}
+	diags.Append(value.ElementsAs(ctx, &out, false)...)
+	return out
+}
+
+// decodeStringList returns the elements of a string list attribute, or an
+// empty slice when the attribute is null or unknown.
+func decodeStringList(ctx context.Context, value types.List, diags *diag.Diagnostics) []string {
+	out := []string{}
+	if value.IsNull() || value.IsUnknown() {
+		return out
+	}
+	diags.Append(value.ElementsAs(ctx, &out, false)...)
+	return out
+}
+
+// resolveWhat returns the resource identifier from `what` or its deprecated
+// alias `resource`.
+func resolveWhat(what, resource types.String, diags *diag.Diagnostics) string {
+	whatValue := strings.TrimSpace(what.ValueString())
+	resourceValue := strings.TrimSpace(resource.ValueString())
+	if whatValue == "" && resourceValue == "" {
+		diags.AddError("Missing required attribute", "Either `what` (preferred) or `resource` must be set.")
+		return ""
+	}
+	if whatValue != "" && resourceValue != "" && whatValue != resourceValue {
+		diags.AddError("Conflicting attributes", "`what` and `resource` cannot both be set to different values.")
+		return ""
+	}
+	if whatValue == "" {
+		return resourceValue
+	}
+	return whatValue
+}
+
+// addBuildError reports a naming error, naming the offending component when
+// there is one.
+func addBuildError(diags *diag.Diagnostics, err error) {
+	var componentErr *naming.ComponentError
+	if errors.As(err, &componentErr) {
+		diags.AddError("Invalid component", err.Error())
+		return
+	}
+	diags.AddError("Name build failed", err.Error())
+}
+
+// addUnknownRegionWarnings reports the regions of a name that are missing
+// from the region map.
+func addUnknownRegionWarnings(diags *diag.Diagnostics, unknown []naming.UnknownRegionError) {
+	for i := range unknown {
+		diags.AddWarning("Unknown region", unknown[i].Error())
+	}
+}
+
+// addConstraintWarnings reports constraint violations whose enforcement
+// level is warn.
+func addConstraintWarnings(diags *diag.Diagnostics, violations []naming.ConstraintError) {
+	for i := range violations {
+		diags.AddWarning("Naming constraint violated", violations[i].Error())
+	}
+}
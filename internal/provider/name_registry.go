@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+// nameRegistry records every name produced by one configured provider
+// instance so that two marks yielding the same name in the same uniqueness
+// scope are reported during plan instead of failing at apply time.
+type nameRegistry struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+func newNameRegistry() *nameRegistry {
+	return &nameRegistry{names: map[string]string{}}
+}
+
+// register records result for the given canonical resource key and request
+// description. It returns the description of the earlier request and false
+// when the name is already taken in the same scope.
+func (r *nameRegistry) register(resourceKey string, result naming.BuildResult, description string) (string, bool) {
+	if r == nil {
+		return "", true
+	}
+	key := strings.Join([]string{
+		resourceKey,
+		result.Scope,
+		strings.ToLower(result.ScopeKey),
+		result.Name,
+	}, "\x00")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if previous, ok := r.names[key]; ok {
+		return previous, false
+	}
+	r.names[key] = description
+	return "", true
+}
+
+// registryKey returns the key names of resource are registered under, so
+// that aliases of one resource type share a uniqueness scope.
+func (p *ProviderData) registryKey(resource string) string {
+	return naming.CanonicalResourceKey(p.Cloud, resource, p.ResourceAliases)
+}
+
+func duplicateNameDetail(resource string, result naming.BuildResult, previous string) string {
+	scope := result.Scope
+	if result.ScopeKey != "" {
+		scope = fmt.Sprintf("%s %q", result.Scope, result.ScopeKey)
+	}
+	return fmt.Sprintf("Name %q for %q was already produced in the same %s scope by an earlier sigil_mark or sigil_mark_sequence with %s. Data source addresses are not available to the provider, so the earlier block is identified by its arguments. Set a distinct qualifier or override, or set scope_key when the resources live in different parents.", result.Name, resource, scope, previous)
+}
@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
 )
 
 type SigilProvider struct {
@@ -23,9 +26,14 @@ type ProviderData struct {
 	OrgPrefix                        string
 	Project                          string
 	Env                              string
+	EnvMap                           map[string]string
+	AllowedEnvs                      []string
 	Region                           string
+	Regions                          []string
 	RegionShortCode                  string
 	RegionMap                        map[string]string
+	MultiRegionLocations             map[string]bool
+	StrictRegions                    bool
 	Recipe                           []string
 	StylePriority                    []string
 	ResourceAcronyms                 map[string]string
@@ -33,6 +41,39 @@ type ProviderData struct {
 	ResourceConstraints              map[string]naming.ResourceConstraint
 	IgnoreRegionForRegionalResources bool
 	RegionalResources                map[string]bool
+	Zone                             string
+	ZonalResources                   map[string]bool
+	DisplayRecipe                    []string
+	DisplayStyle                     string
+	DisplayExpansions                map[string]string
+	DisplayConstraints               map[string]naming.ResourceConstraint
+	ComponentRules                   map[string]naming.ComponentRule
+	ResourceRecipes                  map[string][]string
+	WordSplit                        string
+	Transliteration                  string
+	ResourceScopes                   map[string]string
+	ResourceAffixes                  map[string]naming.ResourceAffix
+	DuplicateDetection               bool
+	ResourceAliases                  map[string]string
+	AcronymCollisionPolicy           string
+	Enforcement                      string
+	EnforcementOverrides             map[string]string
+	DeniedWords                      []string
+	DeniedPatterns                   []*regexp.Regexp
+	DenyReservedWords                bool
+	HashInputs                       []string
+	HashAlgorithm                    string
+	HashEncoding                     string
+	HashLength                       int
+	Account                          string
+	Subscription                     string
+	GCPProject                       string
+	AccountMap                       map[string]string
+
+	namer          *sigil.Namer
+	names          *nameRegistry
+	customAcronyms map[string]bool
+	sources        map[string][]string
 }
 
 type providerModel struct {
@@ -43,14 +84,49 @@ type providerModel struct {
 	Project                          types.String `tfsdk:"project"`
 	Env                              types.String `tfsdk:"env"`
 	Region                           types.String `tfsdk:"region"`
+	Regions                          types.List   `tfsdk:"regions"`
+	StrictRegions                    types.Bool   `tfsdk:"strict_regions"`
 	RegionShortCode                  types.String `tfsdk:"region_short_code"`
+	Zone                             types.String `tfsdk:"zone"`
 	Recipe                           types.List   `tfsdk:"recipe"`
 	StylePriority                    types.List   `tfsdk:"style_priority"`
+	WordSplit                        types.String `tfsdk:"word_split"`
+	Transliteration                  types.String `tfsdk:"transliteration"`
+	DuplicateDetection               types.Bool   `tfsdk:"duplicate_detection"`
 	RegionMap                        types.Map    `tfsdk:"region_map"`
 	RegionOverrides                  types.Map    `tfsdk:"region_overrides"`
+	EnvMap                           types.Map    `tfsdk:"env_map"`
+	AllowedEnvs                      types.List   `tfsdk:"allowed_envs"`
 	ResourceAcronyms                 types.Map    `tfsdk:"resource_acronyms"`
 	ResourceStyleOverrides           types.Map    `tfsdk:"resource_style_overrides"`
+	ResourceRecipes                  types.Map    `tfsdk:"resource_recipes"`
+	ResourceAliases                  types.Map    `tfsdk:"resource_aliases"`
+	AcronymCollisionPolicy           types.String `tfsdk:"acronym_collision_policy"`
 	IgnoreRegionForRegionalResources types.Bool   `tfsdk:"ignore_region_for_regional_resources"`
+	DisplayRecipe                    types.List   `tfsdk:"display_recipe"`
+	DisplayStyle                     types.String `tfsdk:"display_style"`
+	DisplayExpansions                types.Map    `tfsdk:"display_expansions"`
+	ComponentRules                   types.Map    `tfsdk:"component_rules"`
+	Enforcement                      types.String `tfsdk:"enforcement"`
+	EnforcementOverrides             types.Map    `tfsdk:"enforcement_overrides"`
+	DeniedWords                      types.List   `tfsdk:"denied_words"`
+	DeniedPatterns                   types.List   `tfsdk:"denied_patterns"`
+	DenyReservedWords                types.Bool   `tfsdk:"deny_reserved_words"`
+	HashInputs                       types.List   `tfsdk:"hash_inputs"`
+	HashAlgorithm                    types.String `tfsdk:"hash_algorithm"`
+	HashEncoding                     types.String `tfsdk:"hash_encoding"`
+	HashLength                       types.Int64  `tfsdk:"hash_length"`
+	Account                          types.String `tfsdk:"account"`
+	Subscription                     types.String `tfsdk:"subscription"`
+	GCPProject                       types.String `tfsdk:"gcp_project"`
+	AccountMap                       types.Map    `tfsdk:"account_map"`
+}
+
+type componentRuleModel struct {
+	MaxLength     types.Int64  `tfsdk:"max_length"`
+	Pattern       types.String `tfsdk:"pattern"`
+	AllowedValues types.List   `tfsdk:"allowed_values"`
+	Required      types.Bool   `tfsdk:"required"`
 }
 
 type providerConfigModel struct {
@@ -59,14 +135,42 @@ type providerConfigModel struct {
 	Project                          types.String `tfsdk:"project"`
 	Env                              types.String `tfsdk:"env"`
 	Region                           types.String `tfsdk:"region"`
+	Regions                          types.List   `tfsdk:"regions"`
+	StrictRegions                    types.Bool   `tfsdk:"strict_regions"`
 	RegionShortCode                  types.String `tfsdk:"region_short_code"`
+	Zone                             types.String `tfsdk:"zone"`
 	Recipe                           types.List   `tfsdk:"recipe"`
 	StylePriority                    types.List   `tfsdk:"style_priority"`
+	WordSplit                        types.String `tfsdk:"word_split"`
+	Transliteration                  types.String `tfsdk:"transliteration"`
+	DuplicateDetection               types.Bool   `tfsdk:"duplicate_detection"`
 	RegionMap                        types.Map    `tfsdk:"region_map"`
 	RegionOverrides                  types.Map    `tfsdk:"region_overrides"`
+	EnvMap                           types.Map    `tfsdk:"env_map"`
+	AllowedEnvs                      types.List   `tfsdk:"allowed_envs"`
 	ResourceAcronyms                 types.Map    `tfsdk:"resource_acronyms"`
 	ResourceStyleOverrides           types.Map    `tfsdk:"resource_style_overrides"`
+	ResourceRecipes                  types.Map    `tfsdk:"resource_recipes"`
+	ResourceAliases                  types.Map    `tfsdk:"resource_aliases"`
+	AcronymCollisionPolicy           types.String `tfsdk:"acronym_collision_policy"`
 	IgnoreRegionForRegionalResources types.Bool   `tfsdk:"ignore_region_for_regional_resources"`
+	DisplayRecipe                    types.List   `tfsdk:"display_recipe"`
+	DisplayStyle                     types.String `tfsdk:"display_style"`
+	DisplayExpansions                types.Map    `tfsdk:"display_expansions"`
+	ComponentRules                   types.Map    `tfsdk:"component_rules"`
+	Enforcement                      types.String `tfsdk:"enforcement"`
+	EnforcementOverrides             types.Map    `tfsdk:"enforcement_overrides"`
+	DeniedWords                      types.List   `tfsdk:"denied_words"`
+	DeniedPatterns                   types.List   `tfsdk:"denied_patterns"`
+	DenyReservedWords                types.Bool   `tfsdk:"deny_reserved_words"`
+	HashInputs                       types.List   `tfsdk:"hash_inputs"`
+	HashAlgorithm                    types.String `tfsdk:"hash_algorithm"`
+	HashEncoding                     types.String `tfsdk:"hash_encoding"`
+	HashLength                       types.Int64  `tfsdk:"hash_length"`
+	Account                          types.String `tfsdk:"account"`
+	Subscription                     types.String `tfsdk:"subscription"`
+	GCPProject                       types.String `tfsdk:"gcp_project"`
+	AccountMap                       types.Map    `tfsdk:"account_map"`
 }
 
 func New(version string) func() provider.Provider {
@@ -132,32 +236,59 @@ func (p *SigilProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		OrgPrefix:                        "",
 		Project:                          "",
 		Env:                              "",
+		EnvMap:                           map[string]string{},
+		AllowedEnvs:                      []string{},
 		Region:                           "",
+		Regions:                          []string{},
 		RegionShortCode:                  "",
 		RegionMap:                        cloudDefaults.RegionMap,
-		Recipe:                           naming.DefaultRecipe(),
+		MultiRegionLocations:             cloudDefaults.MultiRegionLocations,
+		Recipe:                           []string{},
 		StylePriority:                    naming.DefaultStylePriority(),
 		ResourceAcronyms:                 cloudDefaults.ResourceAcronyms,
 		ResourceStyleOverrides:           cloudDefaults.ResourceStyleOverrides,
 		ResourceConstraints:              cloudDefaults.ResourceConstraints,
 		IgnoreRegionForRegionalResources: true,
 		RegionalResources:                cloudDefaults.RegionalResources,
+		ZonalResources:                   cloudDefaults.ZonalResources,
+		DisplayRecipe:                    naming.DefaultDisplayRecipe(),
+		DisplayStyle:                     naming.DisplayStyleTitle,
+		DisplayExpansions:                map[string]string{},
+		DisplayConstraints:               cloudDefaults.DisplayConstraints,
+		ComponentRules:                   map[string]naming.ComponentRule{},
+		ResourceRecipes:                  map[string][]string{},
+		ResourceScopes:                   cloudDefaults.ResourceScopes,
+		ResourceAffixes:                  cloudDefaults.ResourceAffixes,
+		DuplicateDetection:               true,
+		ResourceAliases:                  cloudDefaults.ResourceAliases,
+		AcronymCollisionPolicy:           naming.AcronymCollisionWarn,
+		Enforcement:                      naming.EnforcementError,
+		EnforcementOverrides:             map[string]string{},
+		DeniedWords:                      []string{},
+		DeniedPatterns:                   []*regexp.Regexp{},
+		HashInputs:                       []string{naming.HashInputName},
+		HashAlgorithm:                    naming.HashAlgorithmSHA256,
+		HashEncoding:                     naming.HashEncodingHex,
+		HashLength:                       naming.DefaultHashLength,
+		AccountMap:                       map[string]string{},
+		customAcronyms:                   map[string]bool{},
+		sources:                          map[string][]string{},
 	}
 
 	if hasBaseConfig {
-		applyProviderConfig(ctx, resp, data, baseConfig)
+		applyProviderConfig(ctx, resp, data, baseConfig, configLayerConfig)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
-	applyProviderConfig(ctx, resp, data, providerConfigFromModel(config))
+	applyProviderConfig(ctx, resp, data, providerConfigFromModel(config), configLayerTopLevel)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	if hasOverrideConfig {
-		applyProviderConfig(ctx, resp, data, overrideConfig)
+		applyProviderConfig(ctx, resp, data, overrideConfig, configLayerOverrides)
 		if resp.Diagnostics.HasError() {
 			return
 		}
@@ -166,12 +297,36 @@ func (p *SigilProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if strings.TrimSpace(data.OrgPrefix) == "" {
 		resp.Diagnostics.AddError("Missing org_prefix", "Set org_prefix at the top level, inside config, or inside overrides.")
 	}
+	if !naming.IsValidWordSplit(data.WordSplit) {
+		resp.Diagnostics.AddError("Invalid word_split", fmt.Sprintf("Unsupported word_split %q. Valid values are %q, %q, and %q.", data.WordSplit, naming.WordSplitAlnum, naming.WordSplitCase, naming.WordSplitCaseDigits))
+	}
+	if !naming.IsValidDisplayStyle(data.DisplayStyle) {
+		resp.Diagnostics.AddError("Invalid display_style", fmt.Sprintf("Unsupported display_style %q. Valid values are %q, %q, and %q.", data.DisplayStyle, naming.DisplayStyleTitle, naming.DisplayStyleSentence, naming.DisplayStyleLower))
+	}
+	if !naming.IsValidTransliteration(data.Transliteration) {
+		resp.Diagnostics.AddError("Invalid transliteration", fmt.Sprintf("Unsupported transliteration %q. Valid values are %q and %q.", data.Transliteration, naming.TransliterationStrip, naming.TransliterationError))
+	}
 	if strings.TrimSpace(data.Env) == "" {
 		resp.Diagnostics.AddError("Missing env", "Set env at the top level, inside config, or inside overrides.")
+	} else if len(data.AllowedEnvs) > 0 && !naming.IsAllowedEnv(data.AllowedEnvs, data.EnvMap, data.Env) {
+		resp.Diagnostics.AddError("Invalid env", fmt.Sprintf("env %q (code %q) is not listed in allowed_envs. Allowed values are: %s.", data.Env, naming.EnvCode(data.EnvMap, data.Env), strings.Join(data.AllowedEnvs, ", ")))
 	}
+	checkAcronymCollisions(resp, data)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	namer, err := sigil.New(sigil.WithConfig(data.namingConfig()))
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid naming configuration", err.Error())
+		return
+	}
+	for _, unknown := range namer.UnknownRegions() {
+		resp.Diagnostics.AddWarning("Unknown region", unknown.Error())
+	}
+	data.namer = namer
+	if data.DuplicateDetection {
+		data.names = newNameRegistry()
+	}
 
 	resp.DataSourceData = data
 	resp.ResourceData = data
@@ -180,6 +335,9 @@ func (p *SigilProvider) Configure(ctx context.Context, req provider.ConfigureReq
 func (p *SigilProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewMarkDataSource,
+		NewMarkSequenceDataSource,
+		NewCatalogDataSource,
+		NewConfigDataSource,
 	}
 }
 
@@ -187,6 +345,24 @@ func (p *SigilProvider) Resources(_ context.Context) []func() resource.Resource
 	return nil
 }
 
+// ConfigSettingPaths returns the settings accepted at the top level of the
+// provider block and inside config and overrides, sorted. Attributes of
+// nested objects are listed as "parent.child". sigil serve checks its policy
+// format against this list.
+func ConfigSettingPaths() []string {
+	paths := []string{}
+	for key, attr := range providerConfigSchemaAttributes() {
+		paths = append(paths, key)
+		if nested, ok := attr.(schema.MapNestedAttribute); ok {
+			for child := range nested.NestedObject.Attributes {
+				paths = append(paths, key+"."+child)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 func providerConfigSchemaAttributes() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"cloud": schema.StringAttribute{
@@ -204,9 +380,19 @@ func providerConfigSchemaAttributes() map[string]schema.Attribute {
 		"region": schema.StringAttribute{
 			Optional: true,
 		},
+		"regions": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"strict_regions": schema.BoolAttribute{
+			Optional: true,
+		},
 		"region_short_code": schema.StringAttribute{
 			Optional: true,
 		},
+		"zone": schema.StringAttribute{
+			Optional: true,
+		},
 		"recipe": schema.ListAttribute{
 			Optional:    true,
 			ElementType: types.StringType,
@@ -223,6 +409,14 @@ func providerConfigSchemaAttributes() map[string]schema.Attribute {
 			Optional:    true,
 			ElementType: types.StringType,
 		},
+		"env_map": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"allowed_envs": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
 		"resource_acronyms": schema.MapAttribute{
 			Optional:    true,
 			ElementType: types.StringType,
@@ -231,9 +425,104 @@ func providerConfigSchemaAttributes() map[string]schema.Attribute {
 			Optional:    true,
 			ElementType: types.ListType{ElemType: types.StringType},
 		},
+		"resource_aliases": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"acronym_collision_policy": schema.StringAttribute{
+			Optional: true,
+		},
+		"resource_recipes": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.ListType{ElemType: types.StringType},
+		},
+		"word_split": schema.StringAttribute{
+			Optional: true,
+		},
+		"transliteration": schema.StringAttribute{
+			Optional: true,
+		},
+		"duplicate_detection": schema.BoolAttribute{
+			Optional: true,
+		},
 		"ignore_region_for_regional_resources": schema.BoolAttribute{
 			Optional: true,
 		},
+		"display_recipe": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"display_style": schema.StringAttribute{
+			Optional: true,
+		},
+		"display_expansions": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"component_rules": schema.MapNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"max_length": schema.Int64Attribute{
+						Optional: true,
+					},
+					"pattern": schema.StringAttribute{
+						Optional: true,
+					},
+					"allowed_values": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"required": schema.BoolAttribute{
+						Optional: true,
+					},
+				},
+			},
+		},
+		"enforcement": schema.StringAttribute{
+			Optional: true,
+		},
+		"enforcement_overrides": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"denied_words": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"denied_patterns": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"deny_reserved_words": schema.BoolAttribute{
+			Optional: true,
+		},
+		"hash_inputs": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"hash_algorithm": schema.StringAttribute{
+			Optional: true,
+		},
+		"hash_encoding": schema.StringAttribute{
+			Optional: true,
+		},
+		"hash_length": schema.Int64Attribute{
+			Optional: true,
+		},
+		"account": schema.StringAttribute{
+			Optional: true,
+		},
+		"subscription": schema.StringAttribute{
+			Optional: true,
+		},
+		"gcp_project": schema.StringAttribute{
+			Optional: true,
+		},
+		"account_map": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
 	}
 }
 
@@ -244,14 +533,42 @@ func providerConfigFromModel(config providerModel) providerConfigModel {
 		Project:                          config.Project,
 		Env:                              config.Env,
 		Region:                           config.Region,
+		Regions:                          config.Regions,
+		StrictRegions:                    config.StrictRegions,
 		RegionShortCode:                  config.RegionShortCode,
+		Zone:                             config.Zone,
 		Recipe:                           config.Recipe,
 		StylePriority:                    config.StylePriority,
+		WordSplit:                        config.WordSplit,
+		Transliteration:                  config.Transliteration,
+		DuplicateDetection:               config.DuplicateDetection,
 		RegionMap:                        config.RegionMap,
 		RegionOverrides:                  config.RegionOverrides,
+		EnvMap:                           config.EnvMap,
+		AllowedEnvs:                      config.AllowedEnvs,
 		ResourceAcronyms:                 config.ResourceAcronyms,
 		ResourceStyleOverrides:           config.ResourceStyleOverrides,
+		ResourceRecipes:                  config.ResourceRecipes,
+		ResourceAliases:                  config.ResourceAliases,
+		AcronymCollisionPolicy:           config.AcronymCollisionPolicy,
 		IgnoreRegionForRegionalResources: config.IgnoreRegionForRegionalResources,
+		DisplayRecipe:                    config.DisplayRecipe,
+		DisplayStyle:                     config.DisplayStyle,
+		DisplayExpansions:                config.DisplayExpansions,
+		Enforcement:                      config.Enforcement,
+		EnforcementOverrides:             config.EnforcementOverrides,
+		DeniedWords:                      config.DeniedWords,
+		DeniedPatterns:                   config.DeniedPatterns,
+		DenyReservedWords:                config.DenyReservedWords,
+		HashInputs:                       config.HashInputs,
+		HashAlgorithm:                    config.HashAlgorithm,
+		HashEncoding:                     config.HashEncoding,
+		HashLength:                       config.HashLength,
+		Account:                          config.Account,
+		Subscription:                     config.Subscription,
+		GCPProject:                       config.GCPProject,
+		AccountMap:                       config.AccountMap,
+		ComponentRules:                   config.ComponentRules,
 	}
 }
 
@@ -281,27 +598,63 @@ func resolveCloud(topLevelCloud types.String, baseConfig providerConfigModel, ha
 	return cloud
 }
 
-func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse, data *ProviderData, config providerConfigModel) {
+func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse, data *ProviderData, config providerConfigModel, layer string) {
 	if !config.Cloud.IsNull() && !config.Cloud.IsUnknown() {
 		data.Cloud = naming.NormalizeCloud(config.Cloud.ValueString())
+		data.setSource("cloud", layer)
 	}
 	if !config.OrgPrefix.IsNull() && !config.OrgPrefix.IsUnknown() {
 		data.OrgPrefix = config.OrgPrefix.ValueString()
+		data.setSource("org_prefix", layer)
 	}
 	if !config.Project.IsNull() && !config.Project.IsUnknown() {
 		data.Project = config.Project.ValueString()
+		data.setSource("project", layer)
 	}
 	if !config.Env.IsNull() && !config.Env.IsUnknown() {
 		data.Env = config.Env.ValueString()
+		data.setSource("env", layer)
 	}
 	if !config.Region.IsNull() && !config.Region.IsUnknown() {
 		data.Region = config.Region.ValueString()
+		data.setSource("region", layer)
+	}
+	if !config.Regions.IsNull() && !config.Regions.IsUnknown() {
+		regions := []string{}
+		resp.Diagnostics.Append(config.Regions.ElementsAs(ctx, &regions, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Regions = regions
+		data.setSource("regions", layer)
+	}
+	if !config.StrictRegions.IsNull() && !config.StrictRegions.IsUnknown() {
+		data.StrictRegions = config.StrictRegions.ValueBool()
+		data.setSource("strict_regions", layer)
 	}
 	if !config.RegionShortCode.IsNull() && !config.RegionShortCode.IsUnknown() {
 		data.RegionShortCode = config.RegionShortCode.ValueString()
+		data.setSource("region_short_code", layer)
+	}
+	if !config.Zone.IsNull() && !config.Zone.IsUnknown() {
+		data.Zone = config.Zone.ValueString()
+		data.setSource("zone", layer)
+	}
+	if !config.WordSplit.IsNull() && !config.WordSplit.IsUnknown() {
+		data.WordSplit = config.WordSplit.ValueString()
+		data.setSource("word_split", layer)
+	}
+	if !config.Transliteration.IsNull() && !config.Transliteration.IsUnknown() {
+		data.Transliteration = config.Transliteration.ValueString()
+		data.setSource("transliteration", layer)
+	}
+	if !config.DuplicateDetection.IsNull() && !config.DuplicateDetection.IsUnknown() {
+		data.DuplicateDetection = config.DuplicateDetection.ValueBool()
+		data.setSource("duplicate_detection", layer)
 	}
 	if !config.IgnoreRegionForRegionalResources.IsNull() && !config.IgnoreRegionForRegionalResources.IsUnknown() {
 		data.IgnoreRegionForRegionalResources = config.IgnoreRegionForRegionalResources.ValueBool()
+		data.setSource("ignore_region_for_regional_resources", layer)
 	}
 	if !config.RegionMap.IsNull() && !config.RegionMap.IsUnknown() {
 		regionMap := map[string]string{}
@@ -311,6 +664,7 @@ func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse,
 		}
 		if len(regionMap) > 0 {
 			data.RegionMap = regionMap
+			data.setSource("region_map", layer)
 		}
 	}
 	if !config.RegionOverrides.IsNull() && !config.RegionOverrides.IsUnknown() {
@@ -322,6 +676,29 @@ func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse,
 		for key, val := range overrides {
 			data.RegionMap[key] = val
 		}
+		data.addSource("region_map", layer+".region_overrides")
+	}
+	if !config.EnvMap.IsNull() && !config.EnvMap.IsUnknown() {
+		envMap := map[string]string{}
+		resp.Diagnostics.Append(config.EnvMap.ElementsAs(ctx, &envMap, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(envMap) > 0 {
+			data.EnvMap = envMap
+			data.setSource("env_map", layer)
+		}
+	}
+	if !config.AllowedEnvs.IsNull() && !config.AllowedEnvs.IsUnknown() {
+		allowed := []string{}
+		resp.Diagnostics.Append(config.AllowedEnvs.ElementsAs(ctx, &allowed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(allowed) > 0 {
+			data.AllowedEnvs = allowed
+			data.setSource("allowed_envs", layer)
+		}
 	}
 	if !config.Recipe.IsNull() && !config.Recipe.IsUnknown() {
 		recipe := []string{}
@@ -331,6 +708,7 @@ func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse,
 		}
 		if len(recipe) > 0 {
 			data.Recipe = recipe
+			data.setSource("recipe", layer)
 		}
 	}
 	if !config.StylePriority.IsNull() && !config.StylePriority.IsUnknown() {
@@ -341,6 +719,7 @@ func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse,
 		}
 		if len(styles) > 0 {
 			data.StylePriority = styles
+			data.setSource("style_priority", layer)
 		}
 	}
 	if !config.ResourceAcronyms.IsNull() && !config.ResourceAcronyms.IsUnknown() {
@@ -349,9 +728,29 @@ func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse,
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		if data.customAcronyms == nil {
+			data.customAcronyms = map[string]bool{}
+		}
 		for key, val := range acronyms {
 			data.ResourceAcronyms[strings.ToLower(key)] = val
+			data.customAcronyms[strings.ToLower(key)] = true
+		}
+		data.addSource("resource_acronyms", layer)
+	}
+	if !config.ResourceAliases.IsNull() && !config.ResourceAliases.IsUnknown() {
+		aliases := map[string]string{}
+		resp.Diagnostics.Append(config.ResourceAliases.ElementsAs(ctx, &aliases, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for key, val := range aliases {
+			data.ResourceAliases[strings.ToLower(key)] = strings.ToLower(val)
 		}
+		data.addSource("resource_aliases", layer)
+	}
+	if !config.AcronymCollisionPolicy.IsNull() && !config.AcronymCollisionPolicy.IsUnknown() {
+		data.AcronymCollisionPolicy = strings.ToLower(strings.TrimSpace(config.AcronymCollisionPolicy.ValueString()))
+		data.setSource("acronym_collision_policy", layer)
 	}
 	if !config.ResourceStyleOverrides.IsNull() && !config.ResourceStyleOverrides.IsUnknown() {
 		overrides := map[string][]string{}
@@ -370,5 +769,320 @@ func applyProviderConfig(ctx context.Context, resp *provider.ConfigureResponse,
 		for key, styles := range overrides {
 			data.ResourceStyleOverrides[key] = styles
 		}
+		data.addSource("resource_style_overrides", layer)
+	}
+	if !config.ResourceRecipes.IsNull() && !config.ResourceRecipes.IsUnknown() {
+		for key, value := range config.ResourceRecipes.Elements() {
+			list, ok := value.(types.List)
+			if !ok {
+				continue
+			}
+			recipe := []string{}
+			resp.Diagnostics.Append(list.ElementsAs(ctx, &recipe, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if len(recipe) > 0 {
+				data.ResourceRecipes[strings.ToLower(key)] = recipe
+			}
+		}
+		data.addSource("resource_recipes", layer)
+	}
+	if !config.DisplayRecipe.IsNull() && !config.DisplayRecipe.IsUnknown() {
+		recipe := []string{}
+		resp.Diagnostics.Append(config.DisplayRecipe.ElementsAs(ctx, &recipe, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(recipe) > 0 {
+			data.DisplayRecipe = recipe
+			data.setSource("display_recipe", layer)
+		}
+	}
+	if !config.DisplayStyle.IsNull() && !config.DisplayStyle.IsUnknown() {
+		data.DisplayStyle = config.DisplayStyle.ValueString()
+		data.setSource("display_style", layer)
+	}
+	if !config.DisplayExpansions.IsNull() && !config.DisplayExpansions.IsUnknown() {
+		expansions := map[string]string{}
+		resp.Diagnostics.Append(config.DisplayExpansions.ElementsAs(ctx, &expansions, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for key, val := range expansions {
+			data.DisplayExpansions[strings.ToLower(key)] = val
+		}
+		data.addSource("display_expansions", layer)
+	}
+	if !config.ComponentRules.IsNull() && !config.ComponentRules.IsUnknown() {
+		rules := map[string]componentRuleModel{}
+		resp.Diagnostics.Append(config.ComponentRules.ElementsAs(ctx, &rules, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for key, model := range rules {
+			rule := naming.ComponentRule{
+				MaxLen:   int(model.MaxLength.ValueInt64()),
+				Required: model.Required.ValueBool(),
+			}
+			if pattern := strings.TrimSpace(model.Pattern.ValueString()); pattern != "" {
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					resp.Diagnostics.AddError("Invalid component rule", fmt.Sprintf("component_rules[%q].pattern is not a valid regular expression: %s", key, err))
+					return
+				}
+				rule.Pattern = re
+			}
+			if !model.AllowedValues.IsNull() && !model.AllowedValues.IsUnknown() {
+				resp.Diagnostics.Append(model.AllowedValues.ElementsAs(ctx, &rule.AllowedValues, false)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+			}
+			data.ComponentRules[strings.ToLower(key)] = rule
+		}
+		data.addSource("component_rules", layer)
+	}
+	if !config.Enforcement.IsNull() && !config.Enforcement.IsUnknown() {
+		data.Enforcement = strings.ToLower(strings.TrimSpace(config.Enforcement.ValueString()))
+		if !naming.IsValidEnforcement(data.Enforcement) {
+			resp.Diagnostics.AddError("Invalid enforcement", fmt.Sprintf("Unsupported enforcement %q. Valid values are %q, %q, and %q.", data.Enforcement, naming.EnforcementError, naming.EnforcementWarn, naming.EnforcementOff))
+			return
+		}
+		data.setSource("enforcement", layer)
+	}
+	if !config.EnforcementOverrides.IsNull() && !config.EnforcementOverrides.IsUnknown() {
+		overrides := map[string]string{}
+		resp.Diagnostics.Append(config.EnforcementOverrides.ElementsAs(ctx, &overrides, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for category, level := range overrides {
+			category = strings.ToLower(strings.TrimSpace(category))
+			level = strings.ToLower(strings.TrimSpace(level))
+			if !naming.IsValidEnforcementCategory(category) {
+				resp.Diagnostics.AddError("Invalid enforcement_overrides", fmt.Sprintf("Unsupported constraint category %q. Valid values are: %s.", category, strings.Join(naming.EnforcementCategories(), ", ")))
+				return
+			}
+			if !naming.IsValidEnforcement(level) {
+				resp.Diagnostics.AddError("Invalid enforcement_overrides", fmt.Sprintf("Unsupported enforcement %q for %q. Valid values are %q, %q, and %q.", level, category, naming.EnforcementError, naming.EnforcementWarn, naming.EnforcementOff))
+				return
+			}
+			data.EnforcementOverrides[category] = level
+		}
+		data.addSource("enforcement_overrides", layer)
+	}
+	if !config.DeniedWords.IsNull() && !config.DeniedWords.IsUnknown() {
+		words := []string{}
+		resp.Diagnostics.Append(config.DeniedWords.ElementsAs(ctx, &words, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.DeniedWords = words
+		data.setSource("denied_words", layer)
+	}
+	if !config.DeniedPatterns.IsNull() && !config.DeniedPatterns.IsUnknown() {
+		values := []string{}
+		resp.Diagnostics.Append(config.DeniedPatterns.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		patterns := make([]*regexp.Regexp, 0, len(values))
+		for i, value := range values {
+			re, err := regexp.Compile(value)
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid denied_patterns", fmt.Sprintf("denied_patterns[%d] is not a valid regular expression: %s", i, err))
+				return
+			}
+			patterns = append(patterns, re)
+		}
+		data.DeniedPatterns = patterns
+		data.setSource("denied_patterns", layer)
+	}
+	if !config.DenyReservedWords.IsNull() && !config.DenyReservedWords.IsUnknown() {
+		data.DenyReservedWords = config.DenyReservedWords.ValueBool()
+		data.setSource("deny_reserved_words", layer)
+	}
+	if !config.HashInputs.IsNull() && !config.HashInputs.IsUnknown() {
+		inputs := []string{}
+		resp.Diagnostics.Append(config.HashInputs.ElementsAs(ctx, &inputs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.HashInputs = inputs
+		data.setSource("hash_inputs", layer)
+	}
+	if !config.HashAlgorithm.IsNull() && !config.HashAlgorithm.IsUnknown() {
+		data.HashAlgorithm = strings.ToLower(strings.TrimSpace(config.HashAlgorithm.ValueString()))
+		if !naming.IsValidHashAlgorithm(data.HashAlgorithm) {
+			resp.Diagnostics.AddError("Invalid hash_algorithm", fmt.Sprintf("Unsupported hash_algorithm %q. Valid values are %q, %q, and %q.", data.HashAlgorithm, naming.HashAlgorithmSHA256, naming.HashAlgorithmSHA1, naming.HashAlgorithmMD5))
+			return
+		}
+		data.setSource("hash_algorithm", layer)
+	}
+	if !config.HashEncoding.IsNull() && !config.HashEncoding.IsUnknown() {
+		data.HashEncoding = strings.ToLower(strings.TrimSpace(config.HashEncoding.ValueString()))
+		if !naming.IsValidHashEncoding(data.HashEncoding) {
+			resp.Diagnostics.AddError("Invalid hash_encoding", fmt.Sprintf("Unsupported hash_encoding %q. Valid values are %q and %q.", data.HashEncoding, naming.HashEncodingHex, naming.HashEncodingBase32))
+			return
+		}
+		data.setSource("hash_encoding", layer)
+	}
+	if !config.HashLength.IsNull() && !config.HashLength.IsUnknown() {
+		length := config.HashLength.ValueInt64()
+		if length < naming.MinHashLength || length > naming.MaxHashLength {
+			resp.Diagnostics.AddError("Invalid hash_length", fmt.Sprintf("hash_length must be between %d and %d, got %d.", naming.MinHashLength, naming.MaxHashLength, length))
+			return
+		}
+		data.HashLength = int(length)
+		data.setSource("hash_length", layer)
+	}
+	if !config.Account.IsNull() && !config.Account.IsUnknown() {
+		data.Account = config.Account.ValueString()
+		data.setSource("account", layer)
+	}
+	if !config.Subscription.IsNull() && !config.Subscription.IsUnknown() {
+		data.Subscription = config.Subscription.ValueString()
+		data.setSource("subscription", layer)
+	}
+	if !config.GCPProject.IsNull() && !config.GCPProject.IsUnknown() {
+		data.GCPProject = config.GCPProject.ValueString()
+		data.setSource("gcp_project", layer)
+	}
+	if !config.AccountMap.IsNull() && !config.AccountMap.IsUnknown() {
+		accountMap := map[string]string{}
+		resp.Diagnostics.Append(config.AccountMap.ElementsAs(ctx, &accountMap, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(accountMap) > 0 {
+			data.AccountMap = accountMap
+			data.setSource("account_map", layer)
+		}
+	}
+}
+
+// checkAcronymCollisions reports resource_acronyms entries that resolve to the
+// same acronym as an unrelated resource. Entries that only repeat a built-in
+// acronym are skipped, so collisions already present in the cloud defaults
+// are not reported.
+func checkAcronymCollisions(resp *provider.ConfigureResponse, data *ProviderData) {
+	if !naming.IsValidAcronymCollisionPolicy(data.AcronymCollisionPolicy) {
+		resp.Diagnostics.AddError("Invalid acronym_collision_policy", fmt.Sprintf("Unsupported acronym_collision_policy %q. Valid values are %q, %q, and %q.", data.AcronymCollisionPolicy, naming.AcronymCollisionWarn, naming.AcronymCollisionError, naming.AcronymCollisionOff))
+		return
+	}
+	if data.AcronymCollisionPolicy == naming.AcronymCollisionOff || len(data.customAcronyms) == 0 {
+		return
+	}
+
+	defaults, err := naming.DefaultCloudDefaults(data.Cloud)
+	if err != nil {
+		resp.Diagnostics.AddError("Cloud defaults error", err.Error())
+		return
+	}
+	resources := make([]string, 0, len(data.customAcronyms))
+	for key := range data.customAcronyms {
+		if defaultAcronym, ok := defaults.ResourceAcronyms[key]; ok && defaultAcronym == data.ResourceAcronyms[key] {
+			continue
+		}
+		resources = append(resources, key)
+	}
+
+	for _, collision := range naming.FindAcronymCollisions(data.ResourceAcronyms, data.ResourceAliases, resources) {
+		detail := fmt.Sprintf("Resources %q and %q both resolve to acronym %q. Change one of the acronyms, or declare %q as an alias of %q in resource_aliases if this is intended.", collision.Resource, collision.Other, collision.Acronym, collision.Resource, collision.Other)
+		if data.AcronymCollisionPolicy == naming.AcronymCollisionError {
+			resp.Diagnostics.AddError("Acronym collision", detail)
+		} else {
+			resp.Diagnostics.AddWarning("Acronym collision", detail)
+		}
+	}
+}
+
+// Configuration layers reported by sigil_config, from lowest to highest
+// precedence.
+const (
+	configLayerDefault   = "default"
+	configLayerConfig    = "config"
+	configLayerTopLevel  = "top_level"
+	configLayerOverrides = "overrides"
+)
+
+// setSource records that layer replaced setting.
+func (p *ProviderData) setSource(setting, layer string) {
+	if p.sources == nil {
+		p.sources = map[string][]string{}
+	}
+	p.sources[setting] = []string{layer}
+}
+
+// addSource records that layer merged entries into setting.
+func (p *ProviderData) addSource(setting, layer string) {
+	if p.sources == nil {
+		p.sources = map[string][]string{}
+	}
+	layers := p.sources[setting]
+	if len(layers) == 0 {
+		layers = []string{configLayerDefault}
+	}
+	if layers[len(layers)-1] != layer {
+		layers = append(layers, layer)
+	}
+	p.sources[setting] = layers
+}
+
+// source returns the layers that set setting, in the order they applied.
+func (p *ProviderData) source(setting string) []string {
+	if layers, ok := p.sources[setting]; ok {
+		return append([]string{}, layers...)
+	}
+	return []string{configLayerDefault}
+}
+
+func (p *ProviderData) namingConfig() naming.Config {
+	return naming.Config{
+		Cloud:                            p.Cloud,
+		OrgPrefix:                        p.OrgPrefix,
+		Project:                          p.Project,
+		Env:                              p.Env,
+		EnvMap:                           p.EnvMap,
+		AllowedEnvs:                      p.AllowedEnvs,
+		Region:                           p.Region,
+		Regions:                          p.Regions,
+		RegionShortCode:                  p.RegionShortCode,
+		RegionMap:                        p.RegionMap,
+		MultiRegionLocations:             p.MultiRegionLocations,
+		StrictRegions:                    p.StrictRegions,
+		Recipe:                           p.Recipe,
+		StylePriority:                    p.StylePriority,
+		ResourceAcronyms:                 p.ResourceAcronyms,
+		ResourceStyleOverrides:           p.ResourceStyleOverrides,
+		ResourceConstraints:              p.ResourceConstraints,
+		IgnoreRegionForRegionalResources: p.IgnoreRegionForRegionalResources,
+		RegionalResources:                p.RegionalResources,
+		Zone:                             p.Zone,
+		ZonalResources:                   p.ZonalResources,
+		DisplayRecipe:                    p.DisplayRecipe,
+		DisplayStyle:                     p.DisplayStyle,
+		DisplayExpansions:                p.DisplayExpansions,
+		DisplayConstraints:               p.DisplayConstraints,
+		ComponentRules:                   p.ComponentRules,
+		ResourceRecipes:                  p.ResourceRecipes,
+		WordSplit:                        p.WordSplit,
+		Transliteration:                  p.Transliteration,
+		ResourceScopes:                   p.ResourceScopes,
+		ResourceAffixes:                  p.ResourceAffixes,
+		Enforcement:                      p.Enforcement,
+		EnforcementOverrides:             p.EnforcementOverrides,
+		DeniedWords:                      p.DeniedWords,
+		DeniedPatterns:                   p.DeniedPatterns,
+		DenyReservedWords:                p.DenyReservedWords,
+		HashInputs:                       p.HashInputs,
+		HashAlgorithm:                    p.HashAlgorithm,
+		HashEncoding:                     p.HashEncoding,
+		HashLength:                       p.HashLength,
+		Account:                          p.Account,
+		Subscription:                     p.Subscription,
+		GCPProject:                       p.GCPProject,
+		AccountMap:                       p.AccountMap,
 	}
 }
@@ -510,6 +510,680 @@ data "sigil_mark" "storage" {
 	})
 }
 
+func TestMarkDataSource_displayName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "gcp"
+  org_prefix = "acme"
+  project    = "pay"
+  env        = "prod"
+  display_expansions = {
+    pay = "Payments"
+  }
+`, `
+data "sigil_mark" "sa" {
+  what      = "google_service_account"
+  qualifier = "worker"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.sa", "name", "acme-pay-prod-gsa-worker"),
+					resource.TestCheckResourceAttr("data.sigil_mark.sa", "display_name", "Acme Payments Prod - Worker"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_awsZonalResourceIncludesZone(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+  region     = "us-east-1"
+  recipe     = ["org", "env", "zone", "resource", "qualifier"]
+`, `
+data "sigil_mark" "subnet" {
+  what      = "subnet"
+  qualifier = "app"
+  zone      = "us-east-1a"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.subnet", "name", "acme-dev-use1a-subn-app"),
+					resource.TestCheckResourceAttr("data.sigil_mark.subnet", "zone_code", "use1a"),
+					resource.TestCheckResourceAttr("data.sigil_mark.subnet", "components.zone", "use1a"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_envMapEmitsCodeAndRaw(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud        = "aws"
+  org_prefix   = "acme"
+  env          = "production"
+  env_map      = { production = "prd" }
+  allowed_envs = ["dev", "prd"]
+  recipe       = ["org", "env", "resource", "qualifier"]
+`, `
+data "sigil_mark" "queue" {
+  what      = "sqs"
+  qualifier = "jobs"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.queue", "name", "acme-prd-sqs-jobs"),
+					resource.TestCheckResourceAttr("data.sigil_mark.queue", "components.env", "prd"),
+					resource.TestCheckResourceAttr("data.sigil_mark.queue", "components.env_raw", "production"),
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_envNotAllowed(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud        = "aws"
+  org_prefix   = "acme"
+  env          = "prod"
+  allowed_envs = ["dev", "prd"]
+`, `
+data "sigil_mark" "queue" {
+  what = "sqs"
+}
+`),
+				ExpectError: regexp.MustCompile("Invalid env"),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_envOverrideNotAllowed(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud        = "aws"
+  org_prefix   = "acme"
+  env          = "dev"
+  allowed_envs = ["dev", "prd"]
+`, `
+data "sigil_mark" "queue" {
+  what      = "sqs"
+  overrides = { environment = "sandbox" }
+}
+`),
+				ExpectError: regexp.MustCompile("Invalid component"),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_componentRuleViolation(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  project    = "payments-platform"
+  env        = "dev"
+  component_rules = {
+    project = { max_length = 8 }
+  }
+`, `
+data "sigil_mark" "queue" {
+  what = "sqs"
+}
+`),
+				ExpectError: regexp.MustCompile(`(?s)Invalid component.*component "proj"`),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_resourceRecipe(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  project    = "iac"
+  env        = "dev"
+  region     = "us-east-1"
+  resource_recipes = {
+    iam_role = ["org", "env", "resource", "qualifier"]
+  }
+`, `
+data "sigil_mark" "role" {
+  what      = "iam_role"
+  qualifier = "app"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.role", "name", "acme-dev-role-app"),
+					resource.TestCheckResourceAttr("data.sigil_mark.role", "recipe_source", "resource"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_wordSplitCase(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+  word_split = "case"
+  recipe     = ["org", "env", "resource", "qualifier"]
+`, `
+data "sigil_mark" "queue" {
+  what      = "sqs"
+  qualifier = "APIGateway"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.queue", "name", "acme-dev-sqs-api-gateway"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_transliteratesProject(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  project    = "Zürich"
+  env        = "dev"
+  recipe     = ["org", "proj", "env", "resource"]
+`, `
+data "sigil_mark" "queue" {
+  what = "sqs"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.queue", "name", "acme-zuerich-dev-sqs"),
+					resource.TestCheckResourceAttr("data.sigil_mark.queue", "components.proj", "Zuerich"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_duplicateName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+  region     = "us-east-1"
+`, `
+data "sigil_mark" "queue_a" {
+  what = "sqs"
+}
+
+data "sigil_mark" "queue_b" {
+  what = "sqs"
+}
+`),
+				ExpectError: regexp.MustCompile("Duplicate name"),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_childOfParent(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "azure"
+  org_prefix = "acme"
+  project    = "payments"
+  env        = "prod"
+  region     = "westeurope"
+`, `
+data "sigil_mark" "vnet" {
+  what      = "azurerm_virtual_network"
+  qualifier = "core"
+}
+
+data "sigil_mark" "subnet" {
+  what      = "azurerm_subnet"
+  qualifier = "app"
+  parent    = data.sigil_mark.vnet.components
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.subnet", "name", "snet-app"),
+					resource.TestCheckResourceAttr("data.sigil_mark.subnet", "scope", "parent"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkSequenceDataSource_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+  recipe     = ["org", "env", "resource", "qualifier", "index"]
+`, `
+data "sigil_mark_sequence" "brokers" {
+  what      = "msk_cluster"
+  qualifier = "broker"
+  size      = 3
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark_sequence.brokers", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.sigil_mark_sequence.brokers", "names.0", "acme-dev-mskc-broker-01"),
+					resource.TestCheckResourceAttr("data.sigil_mark_sequence.brokers", "names.2", "acme-dev-mskc-broker-03"),
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_acronymCollisionError(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud                    = "aws"
+  org_prefix               = "acme"
+  env                      = "dev"
+  acronym_collision_policy = "error"
+  resource_acronyms = {
+    job_queue = "sqs"
+  }
+`, `
+data "sigil_mark" "queue" {
+  what = "job_queue"
+}
+`),
+				ExpectError: regexp.MustCompile("Acronym collision"),
+			},
+		},
+	})
+}
+
+func TestCatalogDataSource_filters(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+`, `
+data "sigil_catalog" "s3" {
+  prefix = "s3"
+  tags   = ["constrained"]
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_catalog.s3", "cloud", "aws"),
+					resource.TestCheckResourceAttr("data.sigil_catalog.s3", "keys.#", "2"),
+					resource.TestCheckResourceAttr("data.sigil_catalog.s3", "resources.s3_bucket.acronym", "s3bk"),
+					resource.TestCheckResourceAttr("data.sigil_catalog.s3", "resources.s3_bucket.max_length", "63"),
+					resource.TestCheckResourceAttr("data.sigil_catalog.s3", "resources.s3_bucket.styles.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestConfigDataSource_sources(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  region     = "eu-west-1"
+  config = {
+    env = "dev"
+  }
+  overrides = {
+    env = "prod"
+    region_overrides = {
+      eu-west-1 = "ew1"
+    }
+  }
+`, `
+data "sigil_config" "current" {}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_config.current", "env", "prod"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "region_code", "ew1"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "sources.env.0", "overrides"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "sources.org_prefix.0", "top_level"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "sources.region_map.#", "2"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "sources.region_map.1", "overrides.region_overrides"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "sources.project.0", "default"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_enforcementWarn(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud       = "aws"
+  org_prefix  = "acme"
+  env         = "dev"
+  enforcement = "warn"
+`, `
+data "sigil_mark" "bucket" {
+  what      = "s3"
+  qualifier = "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.bucket", "name", "acme-dev-s3b-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"),
+				),
+			},
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud       = "aws"
+  org_prefix  = "acme"
+  env         = "dev"
+  enforcement = "warn"
+  enforcement_overrides = {
+    length = "error"
+  }
+`, `
+data "sigil_mark" "bucket" {
+  what      = "s3"
+  qualifier = "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"
+}
+`),
+				ExpectError: regexp.MustCompile("exceeds 63 characters"),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_deniedWords(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud        = "aws"
+  org_prefix   = "acme"
+  env          = "dev"
+  denied_words = ["falcon"]
+`, `
+data "sigil_mark" "queue" {
+  what      = "sqs"
+  qualifier = "falcon-sync"
+}
+`),
+				ExpectError: regexp.MustCompile(`must not contain denied word "falcon"`),
+			},
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud               = "azure"
+  org_prefix          = "acme"
+  env                 = "dev"
+  deny_reserved_words = true
+`, `
+data "sigil_mark" "group" {
+  what      = "azurerm_resource_group"
+  qualifier = "xbox"
+}
+`),
+				ExpectError: regexp.MustCompile(`reserved by azure`),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_hash(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud       = "aws"
+  org_prefix  = "acme"
+  env         = "dev"
+  hash_inputs = ["org", "env", "qualifier"]
+`, `
+data "sigil_mark" "bucket" {
+  what      = "s3"
+  qualifier = "logs"
+  recipe    = ["org", "resource", "qualifier", "hash"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.sigil_mark.bucket", "name", regexp.MustCompile(`^acme-s3b-logs-[0-9a-f]{6}$`)),
+					resource.TestMatchResourceAttr("data.sigil_mark.bucket", "components.hash", regexp.MustCompile(`^[0-9a-f]{6}$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_accountComponents(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud       = "aws"
+  org_prefix  = "acme"
+  env         = "dev"
+  account     = "123456789012"
+  account_map = {
+    "123456789012" = "core"
+  }
+  resource_recipes = {
+    s3 = ["org", "account", "env", "resource", "qualifier"]
+  }
+`, `
+data "sigil_mark" "bucket" {
+  what      = "s3"
+  qualifier = "logs"
+}
+
+data "sigil_config" "current" {}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.bucket", "name", "acme-core-dev-s3b-logs"),
+					resource.TestCheckResourceAttr("data.sigil_mark.bucket", "components.account", "core"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "account_code", "core"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_fifo(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+`, `
+data "sigil_mark" "queue" {
+  what      = "sqs"
+  qualifier = "orders"
+  fifo      = true
+}
+
+data "sigil_mark" "alias" {
+  what      = "kms_alias"
+  qualifier = "orders"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.queue", "name", "acme-dev-sqs-orders.fifo"),
+					resource.TestCheckResourceAttr("data.sigil_mark.alias", "name", "alias/acme-dev-kms-alias-orders"),
+				),
+			},
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+`, `
+data "sigil_mark" "bucket" {
+  what = "s3"
+  fifo = true
+}
+`),
+				ExpectError: regexp.MustCompile(`has no FIFO variant`),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_regions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "aws"
+  org_prefix = "acme"
+  env        = "dev"
+  region     = "eu-west-1"
+`, `
+data "sigil_mark" "single" {
+  what      = "sqs"
+  qualifier = "orders"
+}
+
+data "sigil_mark" "replicated" {
+  what      = "sqs"
+  qualifier = "orders"
+  regions   = ["eu-west-1", "us-east-1"]
+}
+
+data "sigil_mark" "global" {
+  what      = "sqs"
+  qualifier = "orders"
+  regions   = ["global"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.single", "name", "acme-dev-sqs-orders"),
+					resource.TestCheckResourceAttr("data.sigil_mark.replicated", "name", "acme-dev-euw1-use1-sqs-orders"),
+					resource.TestCheckResourceAttr("data.sigil_mark.replicated", "region_code", "euw1-use1"),
+					resource.TestCheckResourceAttr("data.sigil_mark.global", "name", "acme-dev-glb-sqs-orders"),
+				),
+			},
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud      = "gcp"
+  org_prefix = "acme"
+  env        = "dev"
+  regions    = ["europe-west1", "europe-west4"]
+`, `
+data "sigil_config" "current" {}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_config.current", "region_code", "euw1-euw4"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "regions.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestMarkDataSource_strictRegions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud          = "aws"
+  org_prefix     = "acme"
+  env            = "dev"
+  region         = "eu-wset-1"
+  strict_regions = true
+`, `
+data "sigil_mark" "bucket" {
+  what = "s3"
+}
+`),
+				ExpectError: regexp.MustCompile(`did you mean "eu-west-1"\?`),
+			},
+			{
+				Config: testAccMarkDataSourceConfig(`
+  cloud          = "aws"
+  org_prefix     = "acme"
+  env            = "dev"
+  region         = "eu-west-1"
+  strict_regions = true
+`, `
+data "sigil_mark" "bucket" {
+  what = "s3"
+}
+
+data "sigil_config" "current" {}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sigil_mark.bucket", "name", "acme-dev-s3b"),
+					resource.TestCheckResourceAttr("data.sigil_config.current", "strict_regions", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccMarkDataSourceConfig(providerBody, dataBody string) string {
 	return fmt.Sprintf(`
 %s
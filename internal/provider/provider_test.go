@@ -1,8 +1,15 @@
 package provider
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jesinity/terraform-provider-sigil/internal/naming"
 )
@@ -31,3 +38,228 @@ func TestResolveCloudPrecedence(t *testing.T) {
 		t.Fatalf("expected explicit top-level cloud (%q), got %q", naming.CloudGCP, resolved)
 	}
 }
+
+func TestNameRegistryDetectsDuplicatesWithinScope(t *testing.T) {
+	registry := newNameRegistry()
+	result := naming.BuildResult{Name: "acme-dev-sqs", Scope: naming.ScopeRegion, ScopeKey: "use1"}
+
+	if _, ok := registry.register("sqs", result, "first"); !ok {
+		t.Fatal("expected first registration to succeed")
+	}
+	previous, ok := registry.register("sqs", result, "second")
+	if ok {
+		t.Fatal("expected duplicate registration to fail")
+	}
+	if previous != "first" {
+		t.Fatalf("expected previous description %q, got %q", "first", previous)
+	}
+
+	otherRegion := result
+	otherRegion.ScopeKey = "euw1"
+	if _, ok := registry.register("sqs", otherRegion, "third"); !ok {
+		t.Fatal("expected same name in another region to succeed")
+	}
+	if _, ok := registry.register("sns", result, "fourth"); !ok {
+		t.Fatal("expected same name for another resource type to succeed")
+	}
+
+	data := &ProviderData{Cloud: naming.CloudGCP, ResourceAliases: naming.DefaultGCPResourceAliases()}
+	bucket := naming.BuildResult{Name: "acme-dev-gcs", Scope: naming.ScopeGlobal}
+	if _, ok := registry.register(data.registryKey("gcs"), bucket, "gcs"); !ok {
+		t.Fatal("expected first bucket registration to succeed")
+	}
+	if previous, ok := registry.register(data.registryKey("google_storage_bucket"), bucket, "google"); ok || previous != "gcs" {
+		t.Fatalf("expected alias of the same resource to collide with %q, got %q (ok=%v)", "gcs", previous, ok)
+	}
+
+	var disabled *nameRegistry
+	if _, ok := disabled.register("sqs", result, "fifth"); !ok {
+		t.Fatal("expected nil registry to accept every name")
+	}
+}
+
+func TestCheckAcronymCollisionsPolicy(t *testing.T) {
+	newData := func(policy string) *ProviderData {
+		defaults, err := naming.DefaultCloudDefaults(naming.CloudAWS)
+		if err != nil {
+			t.Fatalf("unexpected error loading AWS defaults: %v", err)
+		}
+		defaults.ResourceAcronyms["job_queue"] = "sqs"
+		defaults.ResourceAcronyms["iam_role"] = "role"
+		return &ProviderData{
+			Cloud:                  naming.CloudAWS,
+			ResourceAcronyms:       defaults.ResourceAcronyms,
+			ResourceAliases:        defaults.ResourceAliases,
+			AcronymCollisionPolicy: policy,
+			customAcronyms:         map[string]bool{"job_queue": true, "iam_role": true},
+		}
+	}
+
+	resp := &provider.ConfigureResponse{}
+	checkAcronymCollisions(resp, newData(naming.AcronymCollisionWarn))
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected one warning, got %v", resp.Diagnostics)
+	}
+
+	resp = &provider.ConfigureResponse{}
+	checkAcronymCollisions(resp, newData(naming.AcronymCollisionError))
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", resp.Diagnostics)
+	}
+
+	resp = &provider.ConfigureResponse{}
+	checkAcronymCollisions(resp, newData(naming.AcronymCollisionOff))
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", resp.Diagnostics)
+	}
+}
+
+func TestApplyProviderConfigRecordsSources(t *testing.T) {
+	ctx := context.Background()
+	data := &ProviderData{
+		RegionMap: map[string]string{"us-east-1": "use1"},
+		sources:   map[string][]string{},
+	}
+	resp := &provider.ConfigureResponse{}
+
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		Env:       types.StringValue("dev"),
+		RegionMap: types.MapValueMust(types.StringType, map[string]attr.Value{"eu-west-1": types.StringValue("ew1")}),
+	}, configLayerConfig)
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		Env: types.StringValue("prod"),
+	}, configLayerTopLevel)
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		RegionOverrides: types.MapValueMust(types.StringType, map[string]attr.Value{"eu-west-2": types.StringValue("ew2")}),
+	}, configLayerOverrides)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if got := strings.Join(data.source("env"), ","); got != "top_level" {
+		t.Fatalf("expected env from top_level, got %q", got)
+	}
+	if got := strings.Join(data.source("region_map"), ","); got != "config,overrides.region_overrides" {
+		t.Fatalf("expected region_map replaced by config and merged by overrides, got %q", got)
+	}
+	if _, ok := data.RegionMap["us-east-1"]; ok {
+		t.Fatal("expected region_map to replace the defaults")
+	}
+	if got := strings.Join(data.source("project"), ","); got != "default" {
+		t.Fatalf("expected untouched project to come from default, got %q", got)
+	}
+}
+
+func TestApplyProviderConfigEnforcement(t *testing.T) {
+	ctx := context.Background()
+	data := &ProviderData{
+		Enforcement:          naming.EnforcementError,
+		EnforcementOverrides: map[string]string{},
+		sources:              map[string][]string{},
+	}
+	resp := &provider.ConfigureResponse{}
+
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		Enforcement:          types.StringValue("Warn"),
+		EnforcementOverrides: types.MapValueMust(types.StringType, map[string]attr.Value{"pattern": types.StringValue("error")}),
+	}, configLayerTopLevel)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if data.Enforcement != naming.EnforcementWarn || data.EnforcementOverrides["pattern"] != naming.EnforcementError {
+		t.Fatalf("unexpected enforcement %q %v", data.Enforcement, data.EnforcementOverrides)
+	}
+
+	resp = &provider.ConfigureResponse{}
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		EnforcementOverrides: types.MapValueMust(types.StringType, map[string]attr.Value{"size": types.StringValue("warn")}),
+	}, configLayerOverrides)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an unknown category to fail")
+	}
+}
+
+func TestApplyProviderConfigDeniedPatterns(t *testing.T) {
+	ctx := context.Background()
+	data := &ProviderData{sources: map[string][]string{}}
+	resp := &provider.ConfigureResponse{}
+
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		DeniedWords:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("falcon")}),
+		DeniedPatterns: types.ListValueMust(types.StringType, []attr.Value{types.StringValue(`initech\d*`)}),
+	}, configLayerConfig)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(data.DeniedWords) != 1 || len(data.DeniedPatterns) != 1 {
+		t.Fatalf("unexpected denylist %v %v", data.DeniedWords, data.DeniedPatterns)
+	}
+
+	resp = &provider.ConfigureResponse{}
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		DeniedPatterns: types.ListValueMust(types.StringType, []attr.Value{types.StringValue(`(`)}),
+	}, configLayerTopLevel)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an invalid pattern to fail")
+	}
+}
+
+func TestApplyProviderConfigHash(t *testing.T) {
+	ctx := context.Background()
+	data := &ProviderData{sources: map[string][]string{}}
+	resp := &provider.ConfigureResponse{}
+
+	applyProviderConfig(ctx, resp, data, providerConfigModel{
+		HashInputs:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("org"), types.StringValue("qualifier")}),
+		HashAlgorithm: types.StringValue("SHA1"),
+		HashLength:    types.Int64Value(8),
+	}, configLayerConfig)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if data.HashAlgorithm != "sha1" || data.HashLength != 8 || len(data.HashInputs) != 2 {
+		t.Fatalf("unexpected hash settings %q %d %v", data.HashAlgorithm, data.HashLength, data.HashInputs)
+	}
+
+	resp = &provider.ConfigureResponse{}
+	applyProviderConfig(ctx, resp, data, providerConfigModel{HashLength: types.Int64Value(64)}, configLayerTopLevel)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an out-of-range hash_length to fail")
+	}
+}
+
+func TestInt64BetweenValidator(t *testing.T) {
+	v := int64Between{min: 1, max: naming.MaxSequenceCount}
+	for value, wantError := range map[int64]bool{0: true, 1: false, naming.MaxSequenceCount: false, naming.MaxSequenceCount + 1: true} {
+		resp := &validator.Int64Response{}
+		v.ValidateInt64(context.Background(), validator.Int64Request{Path: path.Root("size"), ConfigValue: types.Int64Value(value)}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Fatalf("size %d: expected error %v, got %v", value, wantError, resp.Diagnostics)
+		}
+	}
+
+	resp := &validator.Int64Response{}
+	v.ValidateInt64(context.Background(), validator.Int64Request{Path: path.Root("size"), ConfigValue: types.Int64Null()}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected a null count to pass, got %v", resp.Diagnostics)
+	}
+}
+
+func TestSchemasValidate(t *testing.T) {
+	ctx := context.Background()
+	p := &SigilProvider{}
+
+	providerResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, providerResp)
+	if diags := providerResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid provider schema: %v", diags)
+	}
+	for _, newDataSource := range p.DataSources(ctx) {
+		dataSource := newDataSource()
+		resp := &datasource.SchemaResponse{}
+		dataSource.Schema(ctx, datasource.SchemaRequest{}, resp)
+		if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+			t.Fatalf("invalid %T schema: %v", dataSource, diags)
+		}
+	}
+}
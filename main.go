@@ -9,6 +9,8 @@ import (
 	"github.com/jesinity/terraform-provider-sigil/internal/provider"
 )
 
+//go:generate go run ./tools/docsgen
+
 var version = "dev"
 
 func main() {
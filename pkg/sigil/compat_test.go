@@ -0,0 +1,210 @@
+package sigil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// frozenFields lists the fields of the exported struct types as of the
+// current major version. The types alias engine types, so this test is what
+// keeps an engine refactor from changing the public API: fields may be
+// added, but the ones listed here must keep their name and type.
+var frozenFields = map[string][]string{
+	"Config": {
+		"Cloud string",
+		"OrgPrefix string",
+		"Project string",
+		"Env string",
+		"EnvMap map[string]string",
+		"AllowedEnvs []string",
+		"Region string",
+		"Regions []string",
+		"RegionShortCode string",
+		"RegionMap map[string]string",
+		"MultiRegionLocations map[string]bool",
+		"StrictRegions bool",
+		"Recipe []string",
+		"StylePriority []string",
+		"ResourceAcronyms map[string]string",
+		"ResourceStyleOverrides map[string][]string",
+		"ResourceConstraints map[string]naming.ResourceConstraint",
+		"IgnoreRegionForRegionalResources bool",
+		"RegionalResources map[string]bool",
+		"Zone string",
+		"ZonalResources map[string]bool",
+		"DisplayRecipe []string",
+		"DisplayStyle string",
+		"DisplayExpansions map[string]string",
+		"DisplayConstraints map[string]naming.ResourceConstraint",
+		"ComponentRules map[string]naming.ComponentRule",
+		"ResourceRecipes map[string][]string",
+		"WordSplit string",
+		"Transliteration string",
+		"ResourceScopes map[string]string",
+		"Enforcement string",
+		"EnforcementOverrides map[string]string",
+		"DeniedWords []string",
+		"DeniedPatterns []*regexp.Regexp",
+		"DenyReservedWords bool",
+		"HashInputs []string",
+		"HashAlgorithm string",
+		"HashEncoding string",
+		"HashLength int",
+		"Account string",
+		"Subscription string",
+		"GCPProject string",
+		"AccountMap map[string]string",
+		"ResourceAffixes map[string]naming.ResourceAffix",
+	},
+	"Input": {
+		"Resource string",
+		"Qualifier string",
+		"Zone string",
+		"Overrides map[string]string",
+		"Recipe []string",
+		"StylePriority []string",
+		"DisplayRecipe []string",
+		"DisplayStyle string",
+		"ScopeKey string",
+		"Parent map[string]string",
+		"ParentMode string",
+		"Fifo bool",
+		"Regions []string",
+	},
+	"Result": {
+		"Name string",
+		"DisplayName string",
+		"Style string",
+		"Components map[string]string",
+		"Parts []string",
+		"RegionCode string",
+		"ZoneCode string",
+		"ResourceAcronym string",
+		"RecipeSource string",
+		"Warnings []string",
+		"Scope string",
+		"ScopeKey string",
+		"ParentMode string",
+		"Violations []naming.ConstraintError",
+		"UnknownRegions []naming.UnknownRegionError",
+	},
+	"SequenceInput": {
+		"Count int",
+		"Start int",
+		"Padding int",
+	},
+	"ResourceConstraint": {
+		"MinLen int",
+		"MaxLen int",
+		"Pattern *regexp.Regexp",
+		"PatternDescription string",
+		"ForbiddenPrefixes []string",
+		"ForbiddenSuffixes []string",
+		"ForbiddenSubstrings []string",
+		"ForbiddenPatterns []*regexp.Regexp",
+		"DisallowIPAddress bool",
+		"CaseInsensitive bool",
+	},
+	"ComponentRule": {
+		"MaxLen int",
+		"Pattern *regexp.Regexp",
+		"AllowedValues []string",
+		"Required bool",
+	},
+	"ParsedName": {
+		"Resource string",
+		"Style string",
+		"Components map[string]string",
+	},
+	"CatalogEntry": {
+		"Resource string",
+		"Acronym string",
+		"Styles []string",
+		"Regional bool",
+		"Zonal bool",
+		"Scope string",
+		"MinLen int",
+		"MaxLen int",
+		"PatternDescription string",
+		"Tags []string",
+	},
+	"CatalogFilter": {
+		"Prefix string",
+		"Tags []string",
+	},
+	"CloudDefaults": {
+		"RegionMap map[string]string",
+		"MultiRegionLocations map[string]bool",
+		"ResourceAcronyms map[string]string",
+		"ResourceStyleOverrides map[string][]string",
+		"ResourceConstraints map[string]naming.ResourceConstraint",
+		"RegionalResources map[string]bool",
+		"ZonalResources map[string]bool",
+		"DisplayConstraints map[string]naming.ResourceConstraint",
+		"ResourceScopes map[string]string",
+		"ResourceAliases map[string]string",
+		"ReservedWords []string",
+		"ResourceAffixes map[string]naming.ResourceAffix",
+	},
+	"ResourceAffix": {
+		"Prefix string",
+		"Suffix string",
+		"Fifo bool",
+	},
+	"ConstraintError": {
+		"Resource string",
+		"Name string",
+		"Category string",
+		"Reason string",
+		"Display bool",
+	},
+	"ComponentError": {
+		"Component string",
+		"Value string",
+		"Reason string",
+	},
+	"UnknownRegionError": {
+		"Region string",
+		"Suggestion string",
+	},
+}
+
+func TestExportedTypesKeepFrozenFields(t *testing.T) {
+	exported := map[string]reflect.Type{
+		"Config":             reflect.TypeOf(Config{}),
+		"Input":              reflect.TypeOf(Input{}),
+		"Result":             reflect.TypeOf(Result{}),
+		"SequenceInput":      reflect.TypeOf(SequenceInput{}),
+		"ResourceConstraint": reflect.TypeOf(ResourceConstraint{}),
+		"ComponentRule":      reflect.TypeOf(ComponentRule{}),
+		"ParsedName":         reflect.TypeOf(ParsedName{}),
+		"CatalogEntry":       reflect.TypeOf(CatalogEntry{}),
+		"CatalogFilter":      reflect.TypeOf(CatalogFilter{}),
+		"CloudDefaults":      reflect.TypeOf(CloudDefaults{}),
+		"ResourceAffix":      reflect.TypeOf(ResourceAffix{}),
+		"ConstraintError":    reflect.TypeOf(ConstraintError{}),
+		"ComponentError":     reflect.TypeOf(ComponentError{}),
+		"UnknownRegionError": reflect.TypeOf(UnknownRegionError{}),
+	}
+	if len(exported) != len(frozenFields) {
+		t.Fatalf("expected %d frozen types, got %d", len(exported), len(frozenFields))
+	}
+	for name, typ := range exported {
+		fields, ok := frozenFields[name]
+		if !ok {
+			t.Fatalf("%s has no frozen field list", name)
+		}
+		for _, field := range fields {
+			fieldName, fieldType, _ := strings.Cut(field, " ")
+			got, ok := typ.FieldByName(fieldName)
+			if !ok {
+				t.Errorf("%s.%s was removed; exported fields must stay within a major version", name, fieldName)
+				continue
+			}
+			if got.Type.String() != fieldType {
+				t.Errorf("%s.%s changed type from %s to %s", name, fieldName, fieldType, got.Type)
+			}
+		}
+	}
+}
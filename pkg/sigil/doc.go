@@ -0,0 +1,37 @@
+// Package sigil builds consistent cloud resource names. It is the public Go
+// API of the naming engine behind the Sigil Terraform provider, for tools
+// such as operators, Pulumi programs, and internal portals that need the
+// same names without going through Terraform.
+//
+// Create a Namer once with New and reuse it; a Namer is immutable and safe
+// for concurrent use:
+//
+//	namer, err := sigil.New(
+//		sigil.WithCloud(sigil.CloudAWS),
+//		sigil.WithOrgPrefix("acme"),
+//		sigil.WithEnv("dev"),
+//		sigil.WithRegion("eu-west-1"),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	result, err := namer.Name(sigil.Input{Resource: "sqs", Qualifier: "orders"})
+//
+// # Errors
+//
+// New returns a *ConfigError for invalid options. Name and Sequence return a
+// *ConstraintError when a name breaks a resource constraint and a
+// *ComponentError when a component breaks a component rule; use errors.As to
+// tell them apart.
+//
+// # Compatibility
+//
+// This package follows semantic versioning together with the module. Within
+// a major version, exported identifiers are not removed or changed
+// incompatibly, new fields may be added to structs, and generated names only
+// change when a release note says so. Config, Input, Result, and the other
+// struct types alias the engine types under internal/; their current fields
+// are frozen by a test in this package, so the engine cannot rename, remove,
+// or retype them without a major version. Everything else under internal/
+// may change at any time.
+package sigil
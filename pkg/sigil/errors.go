@@ -0,0 +1,28 @@
+package sigil
+
+import (
+	"fmt"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+type (
+	// ConstraintError reports a name that violates its resource constraint.
+	ConstraintError = naming.ConstraintError
+	// ComponentError reports a component that violates its component rule.
+	ComponentError = naming.ComponentError
+	// UnknownRegionError reports a region missing from the region map; see
+	// WithStrictRegions.
+	UnknownRegionError = naming.UnknownRegionError
+)
+
+// ConfigError reports an invalid option passed to New.
+type ConfigError struct {
+	Option string
+	Value  string
+	Reason string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Option, e.Value, e.Reason)
+}
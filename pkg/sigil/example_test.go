@@ -0,0 +1,90 @@
+package sigil_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jesinity/terraform-provider-sigil/pkg/sigil"
+)
+
+func ExampleNew() {
+	namer, err := sigil.New(
+		sigil.WithCloud(sigil.CloudAWS),
+		sigil.WithOrgPrefix("acme"),
+		sigil.WithProject("shop"),
+		sigil.WithEnv("dev"),
+		sigil.WithRegion("eu-west-1"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(namer.Cloud(), namer.RegionCode())
+	// Output: aws euw1
+}
+
+func ExampleNamer_Name() {
+	namer, err := sigil.New(
+		sigil.WithOrgPrefix("acme"),
+		sigil.WithProject("shop"),
+		sigil.WithEnv("dev"),
+		sigil.WithRegion("eu-west-1"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	result, err := namer.Name(sigil.Input{Resource: "s3", Qualifier: "assets"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result.Name)
+	// Output: acme-shop-dev-euw1-s3b-assets
+}
+
+func ExampleNamer_Sequence() {
+	namer, err := sigil.New(
+		sigil.WithOrgPrefix("acme"),
+		sigil.WithEnv("dev"),
+		sigil.WithRegion("eu-west-1"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	results, err := namer.Sequence(sigil.Input{Resource: "sqs", Qualifier: "worker"}, sigil.SequenceInput{Count: 2, Start: 1, Padding: 2})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, result := range results {
+		fmt.Println(result.Name)
+	}
+	// Output:
+	// acme-dev-euw1-sqs-worker-01
+	// acme-dev-euw1-sqs-worker-02
+}
+
+func ExampleConstraintError() {
+	namer, err := sigil.New(
+		sigil.WithOrgPrefix("acme"),
+		sigil.WithEnv("dev"),
+		sigil.WithResourceConstraints(map[string]sigil.ResourceConstraint{
+			"sqs": {MaxLen: 10},
+		}),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	_, err = namer.Name(sigil.Input{Resource: "sqs", Qualifier: "orders"})
+	var constraintErr *sigil.ConstraintError
+	if errors.As(err, &constraintErr) {
+		fmt.Println(constraintErr.Category)
+	}
+	// Output: length
+}
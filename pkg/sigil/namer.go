@@ -0,0 +1,172 @@
+package sigil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+// Namer builds names from a fixed configuration. Create it with New; it is
+// immutable and safe for concurrent use.
+type Namer struct {
+	compiled *naming.Compiled
+}
+
+// New validates the options and returns a Namer. The configuration is
+// compiled once here, so later calls do not repeat that work.
+func New(opts ...Option) (*Namer, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cfg := o.config
+	cfg.Cloud = naming.NormalizeCloud(cfg.Cloud)
+	if !naming.IsSupportedCloud(cfg.Cloud) {
+		return nil, &ConfigError{Option: "cloud", Value: cfg.Cloud, Reason: fmt.Sprintf("valid values are %q, %q, and %q", CloudAWS, CloudAzure, CloudGCP)}
+	}
+	if !naming.IsValidWordSplit(cfg.WordSplit) {
+		return nil, &ConfigError{Option: "word split", Value: cfg.WordSplit, Reason: fmt.Sprintf("valid values are %q, %q, and %q", naming.WordSplitAlnum, naming.WordSplitCase, naming.WordSplitCaseDigits)}
+	}
+	if !naming.IsValidDisplayStyle(cfg.DisplayStyle) {
+		return nil, &ConfigError{Option: "display style", Value: cfg.DisplayStyle, Reason: fmt.Sprintf("valid values are %q, %q, and %q", naming.DisplayStyleTitle, naming.DisplayStyleSentence, naming.DisplayStyleLower)}
+	}
+	if !naming.IsValidTransliteration(cfg.Transliteration) {
+		return nil, &ConfigError{Option: "transliteration", Value: cfg.Transliteration, Reason: fmt.Sprintf("valid values are %q and %q", naming.TransliterationStrip, naming.TransliterationError)}
+	}
+	if !naming.IsValidEnforcement(cfg.Enforcement) {
+		return nil, &ConfigError{Option: "enforcement", Value: cfg.Enforcement, Reason: fmt.Sprintf("valid values are %q, %q, and %q", EnforcementError, EnforcementWarn, EnforcementOff)}
+	}
+	for category, level := range cfg.EnforcementOverrides {
+		if !naming.IsValidEnforcementCategory(category) {
+			return nil, &ConfigError{Option: "enforcement category", Value: category, Reason: fmt.Sprintf("valid values are %s", strings.Join(naming.EnforcementCategories(), ", "))}
+		}
+		if !naming.IsValidEnforcement(level) {
+			return nil, &ConfigError{Option: "enforcement", Value: level, Reason: fmt.Sprintf("valid values are %q, %q, and %q", EnforcementError, EnforcementWarn, EnforcementOff)}
+		}
+	}
+	if !naming.IsValidHashAlgorithm(cfg.HashAlgorithm) {
+		return nil, &ConfigError{Option: "hash algorithm", Value: cfg.HashAlgorithm, Reason: fmt.Sprintf("valid values are %q, %q, and %q", HashAlgorithmSHA256, HashAlgorithmSHA1, HashAlgorithmMD5)}
+	}
+	if !naming.IsValidHashEncoding(cfg.HashEncoding) {
+		return nil, &ConfigError{Option: "hash encoding", Value: cfg.HashEncoding, Reason: fmt.Sprintf("valid values are %q and %q", HashEncodingHex, HashEncodingBase32)}
+	}
+	if cfg.HashLength != 0 && (cfg.HashLength < naming.MinHashLength || cfg.HashLength > naming.MaxHashLength) {
+		return nil, &ConfigError{Option: "hash length", Value: strconv.Itoa(cfg.HashLength), Reason: fmt.Sprintf("must be between %d and %d", naming.MinHashLength, naming.MaxHashLength)}
+	}
+	for _, style := range o.stylePriority {
+		if !naming.IsValidStyle(style) {
+			return nil, &ConfigError{Option: "style", Value: style, Reason: "not a supported naming style"}
+		}
+	}
+	for _, styles := range o.styleOverrides {
+		for _, style := range styles {
+			if !naming.IsValidStyle(style) {
+				return nil, &ConfigError{Option: "style", Value: style, Reason: "not a supported naming style"}
+			}
+		}
+	}
+
+	if len(o.deniedPatterns) > 0 {
+		patterns := append([]*regexp.Regexp{}, cfg.DeniedPatterns...)
+		for _, raw := range o.deniedPatterns {
+			pattern, err := regexp.Compile(raw)
+			if err != nil {
+				return nil, &ConfigError{Option: "denied pattern", Value: raw, Reason: err.Error()}
+			}
+			patterns = append(patterns, pattern)
+		}
+		cfg.DeniedPatterns = patterns
+	}
+
+	filled, err := naming.FillCloudDefaults(cfg)
+	if err != nil {
+		return nil, &ConfigError{Option: "cloud", Value: cfg.Cloud, Reason: err.Error()}
+	}
+	cfg = filled
+
+	cfg.RegionMap = overlay(cfg.RegionMap, o.regionOverrides)
+	cfg.ResourceAcronyms = overlay(cfg.ResourceAcronyms, o.acronyms)
+	cfg.ResourceStyleOverrides = overlay(cfg.ResourceStyleOverrides, o.styleOverrides)
+	cfg.ResourceConstraints = overlay(cfg.ResourceConstraints, o.constraints)
+
+	compiled, err := naming.Compile(cfg)
+	if err != nil {
+		var unknown *UnknownRegionError
+		if errors.As(err, &unknown) {
+			reason := "not in the region map"
+			if unknown.Suggestion != "" {
+				reason = fmt.Sprintf("did you mean %q?", unknown.Suggestion)
+			}
+			return nil, &ConfigError{Option: "region", Value: unknown.Region, Reason: reason}
+		}
+		var componentErr *naming.ComponentError
+		if errors.As(err, &componentErr) {
+			return nil, &ConfigError{Option: componentErr.Component, Value: componentErr.Value, Reason: componentErr.Reason}
+		}
+		return nil, &ConfigError{Option: "config", Value: cfg.Cloud, Reason: err.Error()}
+	}
+	return &Namer{compiled: compiled}, nil
+}
+
+// Name builds one name.
+func (n *Namer) Name(in Input) (Result, error) {
+	return n.compiled.BuildName(in)
+}
+
+// Sequence builds a run of indexed names. See SequenceInput.
+func (n *Namer) Sequence(in Input, seq SequenceInput) ([]Result, error) {
+	return n.compiled.BuildSequence(in, seq)
+}
+
+// Validate checks name against the constraint of resource. It returns a
+// *ConstraintError when the name breaks the constraint.
+func (n *Namer) Validate(resource, name string) error {
+	return n.compiled.ValidateName(resource, name)
+}
+
+// Parse splits name back into the components of the recipe resource would
+// use. Only names in a style with a separator can be parsed.
+func (n *Namer) Parse(resource, name string) (ParsedName, error) {
+	return n.compiled.ParseName(resource, name)
+}
+
+// Catalog lists the resource keys the Namer knows about.
+func (n *Namer) Catalog(filter CatalogFilter) []CatalogEntry {
+	return n.compiled.Catalog(filter)
+}
+
+// UnknownRegions returns the configured regions missing from the region map,
+// which are used as-is in names. It is empty with WithStrictRegions(true).
+func (n *Namer) UnknownRegions() []UnknownRegionError {
+	return n.compiled.UnknownRegions()
+}
+
+// Cloud returns the normalized cloud of the Namer.
+func (n *Namer) Cloud() string {
+	return n.compiled.Config().Cloud
+}
+
+// RegionCode returns the region short code used in names.
+func (n *Namer) RegionCode() string {
+	return n.compiled.RegionCode()
+}
+
+// overlay returns base with extra applied on top, without modifying base.
+func overlay[V any](base map[string]V, extra map[string]V) map[string]V {
+	if len(extra) == 0 {
+		return base
+	}
+	out := make(map[string]V, len(base)+len(extra))
+	for key, value := range base {
+		out[key] = value
+	}
+	for key, value := range extra {
+		out[strings.ToLower(key)] = value
+	}
+	return out
+}
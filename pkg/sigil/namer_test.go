@@ -0,0 +1,158 @@
+package sigil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewRejectsInvalidOptions(t *testing.T) {
+	cases := []struct {
+		name   string
+		opts   []Option
+		option string
+	}{
+		{name: "cloud", opts: []Option{WithCloud("oracle")}, option: "cloud"},
+		{name: "word split", opts: []Option{WithWordSplit("words")}, option: "word split"},
+		{name: "transliteration", opts: []Option{WithTransliteration("ascii")}, option: "transliteration"},
+		{name: "style priority", opts: []Option{WithStylePriority("kebab")}, option: "style"},
+		{name: "enforcement", opts: []Option{WithEnforcement("loud")}, option: "enforcement"},
+		{name: "enforcement category", opts: []Option{WithEnforcementOverrides(map[string]string{"size": EnforcementWarn})}, option: "enforcement category"},
+		{name: "denied pattern", opts: []Option{WithDeniedPatterns("(")}, option: "denied pattern"},
+		{name: "hash algorithm", opts: []Option{WithHashAlgorithm("crc32")}, option: "hash algorithm"},
+		{name: "hash length", opts: []Option{WithHashLength(64)}, option: "hash length"},
+		{name: "style override", opts: []Option{WithResourceStyleOverrides(map[string][]string{"sqs": {"kebab"}})}, option: "style"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.opts...)
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("expected ConfigError, got %v", err)
+			}
+			if configErr.Option != tc.option {
+				t.Fatalf("expected option %q, got %q", tc.option, configErr.Option)
+			}
+		})
+	}
+}
+
+func TestNewSkipsUnsupportedConfigStyles(t *testing.T) {
+	namer, err := New(WithConfig(Config{OrgPrefix: "acme", Env: "dev", StylePriority: []string{"kebab", StyleUnderscore}}))
+	if err != nil {
+		t.Fatalf("expected unsupported config styles to be skipped, got %v", err)
+	}
+	result, err := namer.Name(Input{Resource: "sqs", Qualifier: "orders"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Style != StyleUnderscore {
+		t.Fatalf("expected the first supported style, got %q", result.Style)
+	}
+
+	result, err = namer.Name(Input{Resource: "sqs", Qualifier: "orders", StylePriority: []string{"kebab", StyleStraight}})
+	if err != nil {
+		t.Fatalf("expected unsupported input styles to be skipped, got %v", err)
+	}
+	if result.Style != StyleStraight {
+		t.Fatalf("expected the first supported input style, got %q", result.Style)
+	}
+}
+
+func TestNewMergesOverridesIntoDefaults(t *testing.T) {
+	namer, err := New(
+		WithOrgPrefix("acme"),
+		WithEnv("dev"),
+		WithRegion("eu-west-1"),
+		WithRegionOverrides(map[string]string{"eu-west-1": "ire"}),
+		WithResourceAcronyms(map[string]string{"SQS": "queue"}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := namer.Name(Input{Resource: "sqs"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-ire-queue" {
+		t.Fatalf("expected acme-dev-ire-queue, got %q", result.Name)
+	}
+
+	// Defaults not named by an override are kept.
+	result, err = namer.Name(Input{Resource: "sns"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-ire-sns" {
+		t.Fatalf("expected acme-dev-ire-sns, got %q", result.Name)
+	}
+
+	defaults, err := DefaultCloudDefaults(CloudAWS)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if defaults.ResourceAcronyms["sqs"] != "sqs" {
+		t.Fatalf("overrides leaked into the defaults: %q", defaults.ResourceAcronyms["sqs"])
+	}
+}
+
+func TestNamerConstraintError(t *testing.T) {
+	namer, err := New(WithOrgPrefix("acme"), WithEnv("dev"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = namer.Name(Input{Resource: "s3", Qualifier: "this-bucket-name-is-far-too-long-for-s3-to-accept-it-at-all"})
+	var constraintErr *ConstraintError
+	if !errors.As(err, &constraintErr) {
+		t.Fatalf("expected ConstraintError, got %v", err)
+	}
+	if constraintErr.Category != ConstraintLength || constraintErr.Resource != "s3" {
+		t.Fatalf("unexpected constraint error: %+v", constraintErr)
+	}
+}
+
+func TestNamerRegions(t *testing.T) {
+	namer, err := New(WithOrgPrefix("acme"), WithEnv("dev"), WithRegion("eu-west-1"), WithRegions("eu-west-1", "us-east-1"), WithIgnoreRegionForRegionalResources(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := namer.RegionCode(); got != "euw1-use1" {
+		t.Fatalf("expected euw1-use1, got %q", got)
+	}
+
+	// Regional resources keep the region when the name spans several.
+	result, err := namer.Name(Input{Resource: "sqs", Qualifier: "orders"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-euw1-use1-sqs-orders" {
+		t.Fatalf("expected acme-dev-euw1-use1-sqs-orders, got %q", result.Name)
+	}
+
+	result, err = namer.Name(Input{Resource: "sqs", Qualifier: "orders", Regions: []string{"eu-west-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "acme-dev-sqs-orders" {
+		t.Fatalf("expected acme-dev-sqs-orders, got %q", result.Name)
+	}
+}
+
+func TestNamerStrictRegions(t *testing.T) {
+	_, err := New(WithOrgPrefix("acme"), WithEnv("dev"), WithRegion("eu-wset-1"), WithStrictRegions(true))
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || configErr.Option != "region" || configErr.Reason != `did you mean "eu-west-1"?` {
+		t.Fatalf("expected a region ConfigError with a suggestion, got %v", err)
+	}
+
+	namer, err := New(WithOrgPrefix("acme"), WithEnv("dev"), WithRegion("eu-west-1"), WithStrictRegions(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = namer.Name(Input{Resource: "sqs", Regions: []string{"eu-west-1", "us-esat-1"}})
+	var unknown *UnknownRegionError
+	if !errors.As(err, &unknown) || unknown.Suggestion != "us-east-1" {
+		t.Fatalf("expected UnknownRegionError, got %v", err)
+	}
+}
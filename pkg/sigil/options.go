@@ -0,0 +1,360 @@
+package sigil
+
+import "strings"
+
+// Option configures a Namer.
+type Option func(*options)
+
+type options struct {
+	config          Config
+	stylePriority   []string
+	regionOverrides map[string]string
+	acronyms        map[string]string
+	styleOverrides  map[string][]string
+	constraints     map[string]ResourceConstraint
+	deniedPatterns  []string
+}
+
+// WithConfig starts from cfg instead of an empty configuration. Maps left
+// empty in cfg are filled from the cloud defaults. Options after WithConfig
+// still apply on top of it.
+func WithConfig(cfg Config) Option {
+	return func(o *options) {
+		o.config = cfg
+	}
+}
+
+// WithCloud selects the cloud whose defaults are used. Defaults to CloudAWS.
+func WithCloud(cloud string) Option {
+	return func(o *options) {
+		o.config.Cloud = cloud
+	}
+}
+
+// WithOrgPrefix sets the "org" component.
+func WithOrgPrefix(prefix string) Option {
+	return func(o *options) {
+		o.config.OrgPrefix = prefix
+	}
+}
+
+// WithProject sets the "proj" component.
+func WithProject(project string) Option {
+	return func(o *options) {
+		o.config.Project = project
+	}
+}
+
+// WithEnv sets the environment.
+func WithEnv(env string) Option {
+	return func(o *options) {
+		o.config.Env = env
+	}
+}
+
+// WithEnvMap maps environment names to the codes used in names.
+func WithEnvMap(envMap map[string]string) Option {
+	return func(o *options) {
+		o.config.EnvMap = copyStrings(envMap)
+	}
+}
+
+// WithAllowedEnvs restricts the environment, and any env override passed to
+// Build, to the listed names or codes.
+func WithAllowedEnvs(envs ...string) Option {
+	return func(o *options) {
+		o.config.AllowedEnvs = append([]string{}, envs...)
+	}
+}
+
+// WithRegion sets the region, which is shortened through the region map.
+func WithRegion(region string) Option {
+	return func(o *options) {
+		o.config.Region = region
+	}
+}
+
+// WithRegions sets the regions of names that span several regions. Their
+// codes are sorted and joined into one region component (euw1-euw4).
+func WithRegions(regions ...string) Option {
+	return func(o *options) {
+		o.config.Regions = append([]string{}, regions...)
+	}
+}
+
+// WithStrictRegions rejects regions that are not in the region map instead
+// of using them as-is with a warning. New fails on an unknown configured
+// region; Name fails on an unknown region passed in Input.Regions.
+func WithStrictRegions(strict bool) Option {
+	return func(o *options) {
+		o.config.StrictRegions = strict
+	}
+}
+
+// WithRegionShortCode sets the region code directly, bypassing the region map.
+func WithRegionShortCode(code string) Option {
+	return func(o *options) {
+		o.config.RegionShortCode = code
+	}
+}
+
+// WithRegionMap replaces the region map of the cloud defaults.
+func WithRegionMap(regionMap map[string]string) Option {
+	return func(o *options) {
+		o.config.RegionMap = copyStrings(regionMap)
+	}
+}
+
+// WithRegionOverrides adds or replaces entries in the region map.
+func WithRegionOverrides(overrides map[string]string) Option {
+	return func(o *options) {
+		o.regionOverrides = mergeStrings(o.regionOverrides, overrides)
+	}
+}
+
+// WithZone sets the default zone.
+func WithZone(zone string) Option {
+	return func(o *options) {
+		o.config.Zone = zone
+	}
+}
+
+// WithRecipe sets the ordered list of components that make up a name.
+func WithRecipe(recipe ...string) Option {
+	return func(o *options) {
+		o.config.Recipe = append([]string{}, recipe...)
+	}
+}
+
+// WithDisplayRecipe sets the ordered list of components that make up a
+// display name. A "-" entry separates groups of words.
+func WithDisplayRecipe(recipe ...string) Option {
+	return func(o *options) {
+		o.config.DisplayRecipe = append([]string{}, recipe...)
+	}
+}
+
+// WithDisplayStyle sets the casing of display names: "title", "sentence",
+// or "lower".
+func WithDisplayStyle(style string) Option {
+	return func(o *options) {
+		o.config.DisplayStyle = style
+	}
+}
+
+// WithDisplayExpansions adds or replaces the forms words take in display
+// names, such as "eks": "EKS".
+func WithDisplayExpansions(expansions map[string]string) Option {
+	return func(o *options) {
+		o.config.DisplayExpansions = mergeStrings(copyStrings(o.config.DisplayExpansions), expansions)
+	}
+}
+
+// WithStylePriority sets the preferred styles, in order. New rejects
+// unsupported styles passed here; unsupported styles in a Config passed to
+// WithConfig or in Input.StylePriority are skipped, as in the provider.
+func WithStylePriority(styles ...string) Option {
+	return func(o *options) {
+		o.stylePriority = append([]string{}, styles...)
+		o.config.StylePriority = append([]string{}, styles...)
+	}
+}
+
+// WithResourceAcronyms adds or replaces resource acronyms.
+func WithResourceAcronyms(acronyms map[string]string) Option {
+	return func(o *options) {
+		o.acronyms = mergeStrings(o.acronyms, acronyms)
+	}
+}
+
+// WithResourceStyleOverrides adds or replaces the allowed styles of resources.
+func WithResourceStyleOverrides(overrides map[string][]string) Option {
+	return func(o *options) {
+		if o.styleOverrides == nil {
+			o.styleOverrides = map[string][]string{}
+		}
+		for key, styles := range overrides {
+			o.styleOverrides[strings.ToLower(key)] = append([]string{}, styles...)
+		}
+	}
+}
+
+// WithResourceConstraints adds or replaces resource constraints.
+func WithResourceConstraints(constraints map[string]ResourceConstraint) Option {
+	return func(o *options) {
+		if o.constraints == nil {
+			o.constraints = map[string]ResourceConstraint{}
+		}
+		for key, constraint := range constraints {
+			o.constraints[strings.ToLower(key)] = constraint
+		}
+	}
+}
+
+// WithResourceRecipes sets recipes for individual resources.
+func WithResourceRecipes(recipes map[string][]string) Option {
+	return func(o *options) {
+		o.config.ResourceRecipes = map[string][]string{}
+		for key, recipe := range recipes {
+			o.config.ResourceRecipes[strings.ToLower(key)] = append([]string{}, recipe...)
+		}
+	}
+}
+
+// WithComponentRules sets validation rules for component values.
+func WithComponentRules(rules map[string]ComponentRule) Option {
+	return func(o *options) {
+		o.config.ComponentRules = map[string]ComponentRule{}
+		for key, rule := range rules {
+			o.config.ComponentRules[strings.ToLower(key)] = rule
+		}
+	}
+}
+
+// WithIgnoreRegionForRegionalResources drops the region from names of
+// regional resources when enabled.
+func WithIgnoreRegionForRegionalResources(ignore bool) Option {
+	return func(o *options) {
+		o.config.IgnoreRegionForRegionalResources = ignore
+	}
+}
+
+// WithWordSplit selects how component values are split into words.
+func WithWordSplit(mode string) Option {
+	return func(o *options) {
+		o.config.WordSplit = mode
+	}
+}
+
+// WithTransliteration selects how non-ASCII component values are handled.
+func WithTransliteration(policy string) Option {
+	return func(o *options) {
+		o.config.Transliteration = policy
+	}
+}
+
+// WithEnforcement sets how constraint violations are reported: "error"
+// (the default), "warn", or "off". Violations reported as warnings are
+// listed in Result.Violations.
+func WithEnforcement(level string) Option {
+	return func(o *options) {
+		o.config.Enforcement = level
+	}
+}
+
+// WithEnforcementOverrides sets the enforcement level of individual
+// constraint categories: "length", "pattern", "forbidden", and "style".
+func WithEnforcementOverrides(overrides map[string]string) Option {
+	return func(o *options) {
+		o.config.EnforcementOverrides = mergeStrings(o.config.EnforcementOverrides, overrides)
+	}
+}
+
+// WithDeniedWords rejects names that contain any of words as a whole word,
+// ignoring case. A word made of several words, such as "free trial", matches
+// those words in a row.
+func WithDeniedWords(words ...string) Option {
+	return func(o *options) {
+		o.config.DeniedWords = append(o.config.DeniedWords, words...)
+	}
+}
+
+// WithDeniedPatterns rejects names that contain a word matching any of the
+// regular expressions, ignoring case. New returns a *ConfigError for a
+// pattern that does not compile.
+func WithDeniedPatterns(patterns ...string) Option {
+	return func(o *options) {
+		o.deniedPatterns = append(o.deniedPatterns, patterns...)
+	}
+}
+
+// WithDenyReservedWords also rejects the words reserved by the cloud, such
+// as "aws" or "azure".
+func WithDenyReservedWords(deny bool) Option {
+	return func(o *options) {
+		o.config.DenyReservedWords = deny
+	}
+}
+
+// WithHashInputs sets the inputs of the "hash" recipe component: component
+// keys such as "org" or "qualifier", or "name" for the whole name without
+// the hash. Defaults to "name".
+func WithHashInputs(inputs ...string) Option {
+	return func(o *options) {
+		o.config.HashInputs = append([]string{}, inputs...)
+	}
+}
+
+// WithHashAlgorithm selects the hash algorithm: "sha256" (the default),
+// "sha1", or "md5".
+func WithHashAlgorithm(algorithm string) Option {
+	return func(o *options) {
+		o.config.HashAlgorithm = algorithm
+	}
+}
+
+// WithHashEncoding selects the hash encoding: "hex" (the default) or
+// "base32".
+func WithHashEncoding(encoding string) Option {
+	return func(o *options) {
+		o.config.HashEncoding = encoding
+	}
+}
+
+// WithHashLength sets the number of hash characters, from 4 to 16. Defaults
+// to 6.
+func WithHashLength(length int) Option {
+	return func(o *options) {
+		o.config.HashLength = length
+	}
+}
+
+// WithAccount sets the "account" component, such as an AWS account ID. It is
+// shortened through the account map.
+func WithAccount(account string) Option {
+	return func(o *options) {
+		o.config.Account = account
+	}
+}
+
+// WithSubscription sets the "subscription" component, such as an Azure
+// subscription ID. It is shortened through the account map.
+func WithSubscription(subscription string) Option {
+	return func(o *options) {
+		o.config.Subscription = subscription
+	}
+}
+
+// WithGCPProject sets the "gcp_project" component, such as a GCP project ID
+// or number. It is shortened through the account map.
+func WithGCPProject(project string) Option {
+	return func(o *options) {
+		o.config.GCPProject = project
+	}
+}
+
+// WithAccountMap maps account, subscription, and project IDs to the aliases
+// used in names.
+func WithAccountMap(accountMap map[string]string) Option {
+	return func(o *options) {
+		o.config.AccountMap = copyStrings(accountMap)
+	}
+}
+
+func copyStrings(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in))
+	for key, value := range in {
+		out[key] = value
+	}
+	return out
+}
+
+func mergeStrings(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for key, value := range src {
+		dst[strings.ToLower(key)] = value
+	}
+	return dst
+}
@@ -0,0 +1,87 @@
+package sigil
+
+import "github.com/jesinity/terraform-provider-sigil/internal/naming"
+
+// Supported clouds.
+const (
+	CloudAWS   = naming.CloudAWS
+	CloudAzure = naming.CloudAzure
+	CloudGCP   = naming.CloudGCP
+)
+
+// Naming styles.
+const (
+	StyleDashed       = naming.StyleDashed
+	StyleUnderscore   = naming.StyleUnderscore
+	StyleStraight     = naming.StyleStraight
+	StylePascal       = naming.StylePascal
+	StylePascalDashed = naming.StylePascalDashed
+	StyleCamel        = naming.StyleCamel
+)
+
+// Constraint categories reported in ConstraintError.Category.
+const (
+	ConstraintLength    = naming.ConstraintLength
+	ConstraintPattern   = naming.ConstraintPattern
+	ConstraintForbidden = naming.ConstraintForbidden
+	ConstraintStyle     = naming.ConstraintStyle
+)
+
+// Enforcement levels accepted by WithEnforcement and
+// WithEnforcementOverrides.
+const (
+	EnforcementError = naming.EnforcementError
+	EnforcementWarn  = naming.EnforcementWarn
+	EnforcementOff   = naming.EnforcementOff
+)
+
+// FifoSuffix ends the names built with Input.Fifo.
+const FifoSuffix = naming.FifoSuffix
+
+// HashComponent is the recipe item replaced by a short hash; see
+// WithHashInputs.
+const HashComponent = naming.HashComponent
+
+// Hash algorithms accepted by WithHashAlgorithm.
+const (
+	HashAlgorithmSHA256 = naming.HashAlgorithmSHA256
+	HashAlgorithmSHA1   = naming.HashAlgorithmSHA1
+	HashAlgorithmMD5    = naming.HashAlgorithmMD5
+)
+
+// Hash encodings accepted by WithHashEncoding.
+const (
+	HashEncodingHex    = naming.HashEncodingHex
+	HashEncodingBase32 = naming.HashEncodingBase32
+)
+
+type (
+	// Config is the full naming configuration. Most callers use options
+	// instead of filling it in directly.
+	Config = naming.Config
+	// Input describes a single name to build.
+	Input = naming.BuildInput
+	// Result is a built name with the components it was made from.
+	Result = naming.BuildResult
+	// SequenceInput describes a run of indexed names.
+	SequenceInput = naming.SequenceInput
+	// ResourceConstraint limits the names of one resource type.
+	ResourceConstraint = naming.ResourceConstraint
+	// ComponentRule validates a single component value.
+	ComponentRule = naming.ComponentRule
+	// ParsedName is a name split back into its components.
+	ParsedName = naming.ParsedName
+	// CatalogEntry describes one known resource key.
+	CatalogEntry = naming.CatalogEntry
+	// CatalogFilter narrows a catalog listing.
+	CatalogFilter = naming.CatalogFilter
+	// CloudDefaults holds the built-in maps for one cloud.
+	CloudDefaults = naming.CloudDefaults
+	// ResourceAffix holds the fixed prefix and suffix of one resource type.
+	ResourceAffix = naming.ResourceAffix
+)
+
+// DefaultCloudDefaults returns a copy of the built-in defaults for cloud.
+func DefaultCloudDefaults(cloud string) (CloudDefaults, error) {
+	return naming.DefaultCloudDefaults(cloud)
+}
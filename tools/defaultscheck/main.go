@@ -0,0 +1,173 @@
+// Command defaultscheck compares the embedded AWS and GCP resource
+// definitions with a provider schema snapshot produced by
+// `terraform providers schema -json`. The snapshot must cover every provider
+// the definitions reference; the AWS definitions include a Snowflake
+// notification integration.
+//
+// Usage:
+//
+//	terraform providers schema -json > schema.json
+//	go run ./tools/defaultscheck -schema schema.json -cloud aws
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+type providerSchemas struct {
+	ProviderSchemas map[string]struct {
+		ResourceSchemas map[string]json.RawMessage `json:"resource_schemas"`
+	} `json:"provider_schemas"`
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "path to the output of `terraform providers schema -json`")
+	cloud := flag.String("cloud", naming.CloudAWS, "cloud whose definitions are checked (aws or gcp)")
+	flag.Parse()
+
+	if *schemaPath == "" {
+		fmt.Fprintln(os.Stderr, "defaultscheck: -schema is required")
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "defaultscheck: %v\n", err)
+		os.Exit(2)
+	}
+	definitions, err := naming.ResourceDefinitions(*cloud)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "defaultscheck: %v\n", err)
+		os.Exit(2)
+	}
+
+	problems, err := check(os.Stdout, definitions, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "defaultscheck: %v\n", err)
+		os.Exit(2)
+	}
+	if problems > 0 {
+		fmt.Fprintf(os.Stderr, "defaultscheck: %d problem(s) in %s definitions\n", problems, *cloud)
+		os.Exit(1)
+	}
+}
+
+// check reports definitions that do not match the schema snapshot and
+// returns the number of problems found.
+func check(out io.Writer, definitions []naming.ResourceDefinition, snapshot []byte) (int, error) {
+	var schemas providerSchemas
+	if err := json.Unmarshal(snapshot, &schemas); err != nil {
+		return 0, fmt.Errorf("decode schema snapshot: %w", err)
+	}
+	resourceTypes := map[string]bool{}
+	for _, provider := range schemas.ProviderSchemas {
+		for resourceType := range provider.ResourceSchemas {
+			resourceTypes[resourceType] = true
+		}
+	}
+	if len(resourceTypes) == 0 {
+		return 0, fmt.Errorf("schema snapshot lists no resources")
+	}
+
+	problems := 0
+	report := func(format string, args ...any) {
+		problems++
+		fmt.Fprintf(out, "FAIL "+format+"\n", args...)
+	}
+
+	seen := map[string]bool{}
+	scopes := map[string]naming.ResourceDefinition{}
+	for _, definition := range definitions {
+		name := definition.Name
+		if seen[name] {
+			report("%s: duplicate definition", name)
+		}
+		seen[name] = true
+
+		if definition.MaxLength > 0 && definition.MinLength > definition.MaxLength {
+			report("%s: min_length %d exceeds max_length %d", name, definition.MinLength, definition.MaxLength)
+		}
+		if _, err := regexp.Compile(strings.Trim(definition.ValidationRegex, `"`)); err != nil {
+			report("%s: invalid validation_regex: %v", name, err)
+		}
+		for _, pattern := range definition.ForbiddenPatterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				report("%s: invalid forbidden pattern %q: %v", name, pattern, err)
+			}
+		}
+		for _, style := range definition.Styles {
+			if !naming.IsValidStyle(style) {
+				report("%s: unsupported style %q", name, style)
+			}
+		}
+
+		switch {
+		case definition.TerraformType == "":
+			report("%s: missing terraform_type", name)
+		case !resourceTypes[definition.TerraformType]:
+			report("%s: %s is not in the schema snapshot", name, definition.TerraformType)
+		}
+		// Entries for the same resource must agree on the scope, or the
+		// region component depends on which key a caller picks.
+		if definition.TerraformType != "" {
+			target := definition.TerraformType
+			if definition.TerraformBlock != "" {
+				target += "." + definition.TerraformBlock
+			}
+			if first, ok := scopes[target]; !ok {
+				scopes[target] = definition
+			} else if first.Scope != definition.Scope {
+				report("%s: scope %q differs from %s (scope %q) for %s", name, definition.Scope, first.Name, first.Scope, target)
+			}
+		}
+		if definition.SourceURL == "" {
+			report("%s: missing source_url", name)
+		}
+	}
+
+	covered := map[string]bool{}
+	for _, definition := range definitions {
+		covered[definition.TerraformType] = true
+	}
+	prefix := terraformPrefix(definitions)
+	uncovered := []string{}
+	for resourceType := range resourceTypes {
+		if prefix != "" && strings.HasPrefix(resourceType, prefix) && !covered[resourceType] {
+			uncovered = append(uncovered, resourceType)
+		}
+	}
+	sort.Strings(uncovered)
+	fmt.Fprintf(out, "INFO %d of %d %s* resources have no definition\n", len(uncovered), countPrefix(resourceTypes, prefix), prefix)
+
+	return problems, nil
+}
+
+// terraformPrefix returns the provider prefix shared by the definitions,
+// such as "aws_" or "google_".
+func terraformPrefix(definitions []naming.ResourceDefinition) string {
+	for _, definition := range definitions {
+		if i := strings.Index(definition.TerraformType, "_"); i > 0 {
+			return definition.TerraformType[:i+1]
+		}
+	}
+	return ""
+}
+
+func countPrefix(resourceTypes map[string]bool, prefix string) int {
+	count := 0
+	for resourceType := range resourceTypes {
+		if strings.HasPrefix(resourceType, prefix) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+const testSnapshot = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/aws": {
+      "resource_schemas": {
+        "aws_s3_bucket": {"version": 0, "block": {}},
+        "aws_sqs_queue": {"version": 0, "block": {}}
+      }
+    }
+  }
+}`
+
+func TestCheckReportsUnknownTerraformTypes(t *testing.T) {
+	var out strings.Builder
+	problems, err := check(&out, []naming.ResourceDefinition{
+		{Name: "s3_bucket", TerraformType: "aws_s3_bucket", SourceURL: "https://example.com/s3"},
+		{Name: "lambda", TerraformType: "aws_lambda", SourceURL: "https://example.com/lambda"},
+		{Name: "sagemaker"},
+		{Name: "sqs", TerraformType: "aws_sqs_queue", SourceURL: "https://example.com/sqs", Scope: "region", ValidationRegex: "(", Styles: []string{"kebab"}},
+		{Name: "sqs_queue", TerraformType: "aws_sqs_queue", SourceURL: "https://example.com/sqs", Scope: "global"},
+		{Name: "sqs_rule", TerraformType: "aws_sqs_queue", TerraformBlock: "rule", SourceURL: "https://example.com/sqs", Scope: "parent"},
+	}, []byte(testSnapshot))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if problems != 6 {
+		t.Fatalf("expected six problems, got %d:\n%s", problems, out.String())
+	}
+	for _, want := range []string{
+		"FAIL lambda: aws_lambda is not in the schema snapshot",
+		"FAIL sagemaker: missing terraform_type",
+		"FAIL sagemaker: missing source_url",
+		"FAIL sqs: invalid validation_regex",
+		`FAIL sqs: unsupported style "kebab"`,
+		`FAIL sqs_queue: scope "global" differs from sqs (scope "region") for aws_sqs_queue`,
+		"INFO 0 of 2 aws_* resources have no definition",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestEmbeddedDefinitionsMatchTheirOwnTypes(t *testing.T) {
+	for _, cloud := range []string{naming.CloudAWS, naming.CloudGCP} {
+		definitions, err := naming.ResourceDefinitions(cloud)
+		if err != nil {
+			t.Fatalf("unexpected error loading %s definitions: %v", cloud, err)
+		}
+		resources := []string{}
+		for _, definition := range definitions {
+			if definition.TerraformType != "" {
+				resources = append(resources, `"`+definition.TerraformType+`": {}`)
+			}
+		}
+		snapshot := `{"provider_schemas": {"p": {"resource_schemas": {` + strings.Join(resources, ",") + `}}}}`
+
+		var out strings.Builder
+		problems, err := check(&out, definitions, []byte(snapshot))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if problems != 0 {
+			t.Fatalf("expected embedded %s definitions to be consistent, got:\n%s", cloud, out.String())
+		}
+	}
+}
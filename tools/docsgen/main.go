@@ -0,0 +1,285 @@
+// Command docsgen renders the resource tables in the documentation from the
+// cloud defaults. Each table sits between a pair of markers:
+//
+//	<!-- BEGIN GENERATED: aws-acronyms -->
+//	<!-- END GENERATED: aws-acronyms -->
+//
+// Run it from the repository root with `go generate ./...`, or pass -check to
+// report drift without writing.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+// documents lists the files that carry generated tables, relative to the
+// repository root.
+var documents = []string{
+	"README.md",
+	"docs/index.md",
+	"docs/azure-caf-resources.md",
+}
+
+// tables maps a marker name to the function that renders it.
+var tables = map[string]func() (string, error){
+	"aws-acronyms":    renderAWSAcronyms,
+	"aws-constraints": renderAWSConstraints,
+	"gcp-resources":   renderGCPResources,
+	"azure-resources": renderAzureResources,
+}
+
+var blockRe = regexp.MustCompile(`(?s)(<!-- BEGIN GENERATED: ([a-z0-9-]+) -->\n)(.*?)(<!-- END GENERATED: ([a-z0-9-]+) -->)`)
+
+func main() {
+	root := flag.String("root", ".", "repository root")
+	check := flag.Bool("check", false, "report files that are out of date instead of writing them")
+	flag.Parse()
+
+	stale, err := run(*root, !*check)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "docsgen: %v\n", err)
+		os.Exit(2)
+	}
+	if *check && len(stale) > 0 {
+		fmt.Fprintf(os.Stderr, "docsgen: out of date, run `go generate ./...`: %s\n", strings.Join(stale, ", "))
+		os.Exit(1)
+	}
+}
+
+// run renders every document under root and returns the ones whose content
+// changed. When write is true the changed documents are rewritten.
+func run(root string, write bool) ([]string, error) {
+	stale := []string{}
+	for _, document := range documents {
+		path := filepath.Join(root, document)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := render(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", document, err)
+		}
+		if rendered == string(data) {
+			continue
+		}
+		stale = append(stale, document)
+		if write {
+			if err := os.WriteFile(path, []byte(rendered), 0o644); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stale, nil
+}
+
+// render replaces the body of every generated block in content.
+func render(content string) (string, error) {
+	var renderErr error
+	out := blockRe.ReplaceAllStringFunc(content, func(block string) string {
+		match := blockRe.FindStringSubmatch(block)
+		name := match[2]
+		if match[5] != name {
+			renderErr = fmt.Errorf("block %q closed by %q", name, match[5])
+			return block
+		}
+		table, ok := tables[name]
+		if !ok {
+			renderErr = fmt.Errorf("unknown generated block %q", name)
+			return block
+		}
+		body, err := table()
+		if err != nil {
+			renderErr = fmt.Errorf("render %q: %w", name, err)
+			return block
+		}
+		return match[1] + body + match[4]
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+	return out, nil
+}
+
+func renderAWSAcronyms() (string, error) {
+	defaults, err := naming.DefaultCloudDefaults(naming.CloudAWS)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("| Resource | Acronym | Scope |\n| --- | --- | --- |\n")
+	for _, key := range sortedKeys(defaults.ResourceAcronyms) {
+		scope := "global"
+		if defaults.RegionalResources[key] {
+			scope = "regional"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | `%s` |\n", key, defaults.ResourceAcronyms[key], scope)
+	}
+	return b.String(), nil
+}
+
+func renderAWSConstraints() (string, error) {
+	defaults, err := naming.DefaultCloudDefaults(naming.CloudAWS)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("| Resource | Min | Max | Pattern | Notes |\n| --- | --- | --- | --- | --- |\n")
+	for _, key := range sortedKeys(defaults.ResourceConstraints) {
+		constraint := defaults.ResourceConstraints[key]
+		notes := constraintNotes(constraint)
+		if affix, ok := defaults.ResourceAffixes[key]; ok {
+			notes = affixNotes(notes, affix)
+		}
+		fmt.Fprintf(&b, "| `%s` | %d | %d | %s | %s |\n", key, constraint.MinLen, constraint.MaxLen,
+			escapeCell(constraint.PatternDescription), notes)
+	}
+	return b.String(), nil
+}
+
+func renderGCPResources() (string, error) {
+	defaults, err := naming.DefaultCloudDefaults(naming.CloudGCP)
+	if err != nil {
+		return "", err
+	}
+	entries, err := naming.Catalog(naming.Config{Cloud: naming.CloudGCP}, naming.CatalogFilter{})
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("| Resource | Acronym | Scope | Min | Max | Allowed Styles | Notes |\n| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, entry := range entries {
+		minLen, maxLen, notes := "-", "-", "-"
+		if constraint, ok := defaults.ResourceConstraints[entry.Resource]; ok {
+			minLen = fmt.Sprint(constraint.MinLen)
+			maxLen = fmt.Sprint(constraint.MaxLen)
+			notes = constraintNotes(constraint)
+			if notes == "none" {
+				notes = "-"
+			}
+		}
+		styles := "any"
+		if allowed, ok := defaults.ResourceStyleOverrides[entry.Resource]; ok {
+			styles = "`" + strings.Join(allowed, ", ") + "`"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | `%s` | %s | %s | %s | %s |\n", entry.Resource, entry.Acronym, entry.Scope,
+			minLen, maxLen, styles, notes)
+	}
+	return b.String(), nil
+}
+
+func renderAzureResources() (string, error) {
+	defaults, err := naming.DefaultCloudDefaults(naming.CloudAzure)
+	if err != nil {
+		return "", err
+	}
+	definitions, err := naming.ResourceDefinitions(naming.CloudAzure)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+
+	var b strings.Builder
+	b.WriteString("| Resource | Acronym | CAF Scope | Sigil Scope | Min | Max | Dashes | Lowercase Only | Regex | Allowed Styles |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, definition := range definitions {
+		name := strings.ToLower(strings.TrimSpace(definition.Name))
+		scope := "non-regional"
+		if defaults.RegionalResources[name] {
+			scope = "regional"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | `%s` | `%s` | %d | %d | `%t` | `%t` | `%s` | `%s` |\n",
+			definition.Name, defaults.ResourceAcronyms[name], definition.Scope, scope, definition.MinLength, definition.MaxLength,
+			definition.Dashes, definition.Lowercase, escapeCell(strings.Trim(definition.ValidationRegex, `"`)),
+			strings.Join(defaults.ResourceStyleOverrides[name], ", "))
+	}
+	return b.String(), nil
+}
+
+// constraintNotes summarizes the forbidden values of a constraint.
+func constraintNotes(constraint naming.ResourceConstraint) string {
+	notes := []string{}
+	add := func(singular, plural string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		label := plural
+		if len(values) == 1 {
+			label = singular
+		}
+		quoted := make([]string, 0, len(values))
+		for _, value := range values {
+			quoted = append(quoted, "`"+escapeCell(value)+"`")
+		}
+		notes = append(notes, label+": "+strings.Join(quoted, ", "))
+	}
+	add("forbidden prefix", "forbidden prefixes", constraint.ForbiddenPrefixes)
+	add("forbidden suffix", "forbidden suffixes", constraint.ForbiddenSuffixes)
+	add("forbidden substring", "forbidden substrings", constraint.ForbiddenSubstrings)
+	patterns := make([]string, 0, len(constraint.ForbiddenPatterns))
+	for _, pattern := range constraint.ForbiddenPatterns {
+		patterns = append(patterns, pattern.String())
+	}
+	add("forbidden pattern", "forbidden patterns", patterns)
+	if constraint.DisallowIPAddress {
+		notes = append(notes, "disallow IPv4")
+	}
+	if len(notes) == 0 {
+		return "none"
+	}
+
+	out := strings.Join(notes, "; ")
+	if constraint.CaseInsensitive {
+		out += " (case-insensitive)"
+	}
+	return strings.ToUpper(out[:1]) + out[1:]
+}
+
+// affixNotes adds the fixed decorations of a resource to notes.
+func affixNotes(notes string, affix naming.ResourceAffix) string {
+	added := []string{}
+	if affix.Prefix != "" {
+		added = append(added, "fixed prefix: `"+escapeCell(affix.Prefix)+"`")
+	}
+	if affix.Suffix != "" {
+		added = append(added, "fixed suffix: `"+escapeCell(affix.Suffix)+"`")
+	}
+	if affix.Fifo {
+		added = append(added, "`"+naming.FifoSuffix+"` with `fifo = true`")
+	}
+	if len(added) == 0 {
+		return notes
+	}
+	out := strings.Join(added, "; ")
+	if notes != "none" {
+		return notes + "; " + out
+	}
+	return strings.ToUpper(out[:1]) + out[1:]
+}
+
+// escapeCell keeps pipes from splitting a Markdown table cell.
+func escapeCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
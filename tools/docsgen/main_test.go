@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jesinity/terraform-provider-sigil/internal/naming"
+)
+
+func TestCommittedDocsAreUpToDate(t *testing.T) {
+	stale, err := run("../..", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stale) > 0 {
+		t.Fatalf("generated tables are out of date in %s; run `go generate ./...`", strings.Join(stale, ", "))
+	}
+}
+
+func TestRenderReplacesBlockBody(t *testing.T) {
+	out, err := render("intro\n<!-- BEGIN GENERATED: aws-acronyms -->\nstale\n<!-- END GENERATED: aws-acronyms -->\noutro\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(out, "stale") || !strings.Contains(out, "| `s3_bucket` | `s3bk` | `regional` |") {
+		t.Fatalf("expected rendered table, got:\n%s", out)
+	}
+	if !strings.HasPrefix(out, "intro\n") || !strings.HasSuffix(out, "<!-- END GENERATED: aws-acronyms -->\noutro\n") {
+		t.Fatalf("expected surrounding text to be kept, got:\n%s", out)
+	}
+
+	if _, err := render("<!-- BEGIN GENERATED: unknown -->\n<!-- END GENERATED: unknown -->"); err == nil {
+		t.Fatal("expected error for unknown block")
+	}
+}
+
+func TestConstraintNotes(t *testing.T) {
+	constraint := naming.ResourceConstraint{
+		ForbiddenPrefixes: []string{"sg-"},
+		DisallowIPAddress: true,
+		CaseInsensitive:   true,
+	}
+	if got := constraintNotes(constraint); got != "Forbidden prefix: `sg-`; disallow IPv4 (case-insensitive)" {
+		t.Fatalf("unexpected notes: %q", got)
+	}
+}